@@ -0,0 +1,325 @@
+package jsonrepair
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// NodeKind
+//
+//	Description: JSON type of a Node
+type NodeKind int
+
+const (
+	NullNode NodeKind = iota
+	BoolNode
+	NumberNode
+	StringNode
+	ArrayNode
+	ObjectNode
+)
+
+// String
+//
+//	Description:
+//	receiver k
+//	return string
+func (k NodeKind) String() string {
+	switch k {
+	case NullNode:
+		return "null"
+	case BoolNode:
+		return "bool"
+	case NumberNode:
+		return "number"
+	case StringNode:
+		return "string"
+	case ArrayNode:
+		return "array"
+	case ObjectNode:
+		return "object"
+	}
+	return "NodeKind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Member
+//
+//	Description: a single key/value pair of an object node, in source order.
+//	Start and End delimit the key and Repairs lists the repairs applied to it.
+type Member struct {
+	Key     string
+	Start   int
+	End     int
+	Repairs []RepairFlag
+	Value   *Node
+}
+
+// Node
+//
+//	Description: a single value of a parsed (and possibly repaired) document.
+//
+//	Value holds the scalar value (nil, bool, int, float64, json.Number or
+//	string) and is nil for containers. Numbers of input that is already
+//	valid JSON are kept as json.Number, so they are written back verbatim.
+//	Array elements are in Children, object members in Members, both in
+//	source order. Start and End are byte offsets into the normalized
+//	input (code fences and comments stripped, full-width punctuation folded);
+//	synthesized nodes have Start == End. Repairs lists what the parser had to
+//	change to produce this node.
+//
+//	Comments holds the source comments preceding the node (for an object
+//	member, preceding its key) and InnerComments those between the last
+//	element of a container and its closing bracket.
+type Node struct {
+	Kind     NodeKind
+	Value    any
+	Children []*Node
+	Members  []Member
+	Start    int
+	End      int
+	Repairs  []RepairFlag
+
+	Comments      []string
+	InnerComments []string
+}
+
+// newScalarNode builds a scalar node, deriving the kind from the Go type of v.
+func newScalarNode(v any, start, end int) *Node {
+	n := &Node{Value: v, Start: start, End: end}
+	switch v.(type) {
+	case nil:
+		n.Kind = NullNode
+	case bool:
+		n.Kind = BoolNode
+	case int, float64, json.Number:
+		n.Kind = NumberNode
+	default:
+		n.Kind = StringNode
+	}
+	return n
+}
+
+// isEmpty reports whether n is the parser's "nothing parsed" sentinel,
+// which is an empty string node.
+func (n *Node) isEmpty() bool {
+	return n == nil || (n.Kind == StringNode && n.Value == "")
+}
+
+// str returns the value of a string node, or "" for any other node.
+func (n *Node) str() string {
+	if n == nil {
+		return ""
+	}
+	s, _ := n.Value.(string)
+	return s
+}
+
+// addRepair records flag on n once.
+func (n *Node) addRepair(flag RepairFlag) {
+	for _, f := range n.Repairs {
+		if f == flag {
+			return
+		}
+	}
+	n.Repairs = append(n.Repairs, flag)
+}
+
+// HasRepair
+//
+//	Description: reports whether flag was recorded on n
+//	receiver n
+//	param flag
+//	return bool
+func (n *Node) HasRepair(flag RepairFlag) bool {
+	for _, f := range n.Repairs {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// member returns the index of key in n.Members, or -1.
+func (n *Node) member(key string) int {
+	for i, m := range n.Members {
+		if m.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// attachComment stores c on the first node that follows it, or on the
+// innermost container it is in when nothing follows it there.
+func (n *Node) attachComment(c comment) {
+	if c.Pos <= n.Start {
+		n.Comments = append(n.Comments, c.Text)
+		return
+	}
+
+	elements := append([]*Node{}, n.Children...)
+	for _, m := range n.Members {
+		elements = append(elements, m.Value)
+	}
+
+	for _, e := range elements {
+		if c.Pos <= e.Start || (c.Pos < e.End && (e.Kind == ArrayNode || e.Kind == ObjectNode)) {
+			e.attachComment(c)
+			return
+		}
+	}
+	n.InnerComments = append(n.InnerComments, c.Text)
+}
+
+// Interface
+//
+//	Description: converts the tree into map[string]any, []any and scalar values
+//	receiver n
+//	return any
+func (n *Node) Interface() any {
+	if n == nil {
+		return nil
+	}
+	switch n.Kind {
+	case ArrayNode:
+		rst := make([]any, 0, len(n.Children))
+		for _, c := range n.Children {
+			rst = append(rst, c.Interface())
+		}
+		return rst
+	case ObjectNode:
+		rst := make(map[string]any, len(n.Members))
+		for _, m := range n.Members {
+			rst[m.Key] = m.Value.Interface()
+		}
+		return rst
+	}
+	return n.Value
+}
+
+// WalkFunc
+//
+//	Description: called by Walk for every node. path is the JSON Pointer
+//	(RFC 6901) of the node; returning false skips its children.
+type WalkFunc func(path string, n *Node) bool
+
+// Walk
+//
+//	Description: visits n and all of its descendants in source order
+//	param n
+//	param fn
+func Walk(n *Node, fn WalkFunc) {
+	walk("", n, fn)
+}
+
+func walk(path string, n *Node, fn WalkFunc) {
+	if n == nil || !fn(path, n) {
+		return
+	}
+	for i, c := range n.Children {
+		walk(path+"/"+strconv.Itoa(i), c, fn)
+	}
+	for _, m := range n.Members {
+		walk(path+"/"+escapePointerToken(m.Key), m.Value, fn)
+	}
+}
+
+// escapePointerToken escapes a reference token as required by RFC 6901.
+func escapePointerToken(s string) string {
+	if !strings.ContainsAny(s, "~/") {
+		return s
+	}
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+// ParseAST
+//
+//	Description: parses src like RepairJSON but returns the document tree,
+//	including positions and the repairs applied to every node.
+//	param src
+//	param opts
+//	return root
+//	return err
+func ParseAST(src string, opts ...Option) (root *Node, err error) {
+	defer func() {
+		if errR := recover(); errR != nil {
+			stack := string(debug.Stack())
+			err = fmt.Errorf("repair json panic: %s", stack)
+			return
+		}
+	}()
+
+	_, root, _, err = repairDocument(src, true, newOptions(opts))
+	return
+}
+
+// buildValidAST builds the tree of a document that is already valid JSON,
+// so that escapes and number formats are decoded exactly as encoding/json does.
+func buildValidAST(src string) (*Node, error) {
+	dec := json.NewDecoder(strings.NewReader(src))
+	dec.UseNumber()
+
+	// tokenStart returns the offset of the next token, skipping whitespace
+	// and the separators that Token() consumes silently.
+	tokenStart := func() int {
+		i := int(dec.InputOffset())
+		for i < len(src) && bytes.IndexByte([]byte{' ', '\t', '\r', '\n', ',', ':'}, src[i]) != -1 {
+			i++
+		}
+		return i
+	}
+
+	var build func() (*Node, error)
+	build = func() (*Node, error) {
+		start := tokenStart()
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case json.Delim:
+			n := &Node{Kind: ArrayNode, Start: start}
+			if t == '{' {
+				n.Kind = ObjectNode
+			}
+			for dec.More() {
+				if n.Kind == ArrayNode {
+					c, err := build()
+					if err != nil {
+						return nil, err
+					}
+					n.Children = append(n.Children, c)
+					continue
+				}
+
+				keyStart := tokenStart()
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, _ := keyTok.(string)
+				keyEnd := int(dec.InputOffset())
+				v, err := build()
+				if err != nil {
+					return nil, err
+				}
+				n.Members = append(n.Members, Member{Key: key, Start: keyStart, End: keyEnd, Value: v})
+			}
+			// consume the closing delimiter
+			if _, err := dec.Token(); err != nil {
+				return nil, err
+			}
+			n.End = int(dec.InputOffset())
+			return n, nil
+		default:
+			return newScalarNode(t, start, int(dec.InputOffset())), nil
+		}
+	}
+
+	return build()
+}
@@ -0,0 +1,150 @@
+package jsonrepair
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// Test_ParseAST
+//
+//	Description:
+//	param t
+func Test_ParseAST(t *testing.T) {
+	type visited struct {
+		path    string
+		kind    NodeKind
+		repairs []RepairFlag
+	}
+
+	tests := []struct {
+		in   string
+		want []visited
+	}{
+		{
+			in: `{"a": [1, "x"], "b": null}`,
+			want: []visited{
+				{"", ObjectNode, nil},
+				{"/a", ArrayNode, nil},
+				{"/a/0", NumberNode, nil},
+				{"/a/1", StringNode, nil},
+				{"/b", NullNode, nil},
+			},
+		},
+		{
+			in: `{"a": [1, 2,], "b": TRUE, c: x`,
+			want: []visited{
+				{"", ObjectNode, []RepairFlag{RepairUnclosedObject}},
+				{"/a", ArrayNode, []RepairFlag{RepairTrailingComma}},
+				{"/a/0", NumberNode, nil},
+				{"/a/1", NumberNode, nil},
+				{"/b", BoolNode, []RepairFlag{RepairLiteralCase}},
+				{"/c", StringNode, []RepairFlag{RepairMissingQuotes, RepairTruncatedToken}},
+			},
+		},
+		{
+			in: `{'a/b': "abc`,
+			want: []visited{
+				{"", ObjectNode, []RepairFlag{RepairUnclosedObject}},
+				{"/a~1b", StringNode, []RepairFlag{RepairUnclosedString, RepairTruncatedToken}},
+			},
+		},
+		{
+			in: "```json\n[1, 2\n```",
+			want: []visited{
+				{"", ArrayNode, []RepairFlag{RepairUnclosedArray, RepairCodeFence}},
+				{"/0", NumberNode, nil},
+				{"/1", NumberNode, []RepairFlag{RepairTruncatedToken}},
+			},
+		},
+		{
+			in: `{"a":1}{"b":2}`,
+			want: []visited{
+				{"", ArrayNode, []RepairFlag{RepairMultipleRoots}},
+				{"/0", ObjectNode, nil},
+				{"/0/a", NumberNode, nil},
+				{"/1", ObjectNode, nil},
+				{"/1/b", NumberNode, nil},
+			},
+		},
+		{
+			in: `{"name": "John is "good",hah", "age": 30}`,
+			want: []visited{
+				{"", ObjectNode, nil},
+				{"/name", StringNode, []RepairFlag{RepairEmbeddedQuote}},
+				{"/age", NumberNode, nil},
+			},
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			root, err := ParseAST(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []visited
+			Walk(root, func(path string, n *Node) bool {
+				got = append(got, visited{path, n.Kind, n.Repairs})
+				return true
+			})
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAST() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+
+			// The tree must agree with RepairJSON
+			bs, _ := JSONMarshal(root.Interface())
+			if want := MustRepairJSON(tt.in); !jsonStringsEqual(string(bs), want) {
+				t.Errorf("Interface() = %s, want %s", bs, want)
+			}
+		})
+	}
+}
+
+// Test_ParseAST_Positions
+//
+//	Description:
+//	param t
+func Test_ParseAST_Positions(t *testing.T) {
+	for _, in := range []string{
+		`{"key": "value", "list": [1, true, "x"]}`,
+		`{"key": "value", "list": [1, true, "x"`,
+		`{key: 'value', "list": [1, TRUE, x]}`,
+	} {
+		root, err := ParseAST(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := root.Members[0]; in[got.Start:got.End] == "" || got.Key != "key" {
+			t.Errorf("key position = [%d,%d] in %v", got.Start, got.End, in)
+		}
+		if got := root.Members[0].Value; got.Value != "value" || in[got.Start+1:got.End-1] != "value" {
+			t.Errorf("value position = [%d,%d] in %v", got.Start, got.End, in)
+		}
+		if got := root.Members[1].Value.Children[1]; got.Kind != BoolNode || in[got.Start:got.End] != "true" && in[got.Start:got.End] != "TRUE" {
+			t.Errorf("element position = [%d,%d] in %v", got.Start, got.End, in)
+		}
+	}
+}
+
+// Test_Walk_Skip
+//
+//	Description:
+//	param t
+func Test_Walk_Skip(t *testing.T) {
+	root, err := ParseAST(`{"a": {"b": 1}, "c": [2]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	Walk(root, func(path string, n *Node) bool {
+		got = append(got, path)
+		return n.Kind != ObjectNode || path == ""
+	})
+	if want := []string{"", "/a", "/c", "/c/0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Walk() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,58 @@
+// Command capi exports the repairer to C and C++ through cgo. Build it with
+// -buildmode=c-shared or -buildmode=c-archive and include jsonrepair.h;
+// see that header for the API.
+package main
+
+/*
+#include <stdlib.h>
+#include <stddef.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	"github.com/RealAlexandreAI/json-repair"
+)
+
+// main is required by the c-shared and c-archive build modes.
+func main() {}
+
+// jsonrepair_repair
+//
+//	Description: repairs the n bytes at src into a C string stored in out
+//	param src
+//	param n
+//	param out
+//	param outlen
+//	return 0 on success, -1 with an error message in out on failure
+//
+//export jsonrepair_repair
+func jsonrepair_repair(src *C.char, n C.size_t, out **C.char, outlen *C.size_t) C.int {
+	if out == nil || outlen == nil {
+		return -1
+	}
+	var in string
+	if src != nil && n > 0 {
+		in = string(unsafe.Slice((*byte)(unsafe.Pointer(src)), int(n)))
+	}
+
+	dst, err := jsonrepair.RepairJSON(in)
+	status := C.int(0)
+	if err != nil {
+		dst, status = err.Error(), -1
+	}
+	*out = C.CString(dst)
+	*outlen = C.size_t(len(dst))
+	return status
+}
+
+// jsonrepair_free
+//
+//	Description: releases a string returned by jsonrepair_repair
+//	param p
+//
+//export jsonrepair_free
+func jsonrepair_free(p *C.char) {
+	C.free(unsafe.Pointer(p))
+}
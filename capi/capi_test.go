@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// cProgram repairs each argument through the shared library and prints the
+// status and result of every call on its own line.
+const cProgram = `#include <stdio.h>
+#include <string.h>
+#include "jsonrepair.h"
+
+int main(int argc, char **argv) {
+	for (int i = 1; i < argc; i++) {
+		char *out = NULL;
+		size_t outlen = 0;
+		int rc = jsonrepair_repair(argv[i], strlen(argv[i]), &out, &outlen);
+		printf("%d %.*s\n", rc, (int)outlen, out);
+		jsonrepair_free(out);
+	}
+	return 0;
+}
+`
+
+// Test_CSharedLibrary
+//
+//	Description: builds the c-shared library and calls it from a C program;
+//	skips unless running on Linux with cgo and a C compiler
+//	param t
+func Test_CSharedLibrary(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping c-shared build in short mode")
+	}
+	if runtime.GOOS != "linux" {
+		t.Skip("c-shared test only runs on Linux")
+	}
+	cc, err := exec.LookPath("cc")
+	if err != nil {
+		t.Skip("no C compiler installed")
+	}
+	gobin := filepath.Join(runtime.GOROOT(), "bin", "go")
+	if out, err := exec.Command(gobin, "env", "CGO_ENABLED").Output(); err != nil || strings.TrimSpace(string(out)) != "1" {
+		t.Skip("cgo is disabled")
+	}
+
+	dir := t.TempDir()
+	build := exec.Command(gobin, "build", "-buildmode=c-shared", "-o", filepath.Join(dir, "libjsonrepair.so"), ".")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("go build: %v\n%s", err, out)
+	}
+
+	src := filepath.Join(dir, "main.c")
+	if err := os.WriteFile(src, []byte(cProgram), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	prog := filepath.Join(dir, "main")
+	compile := exec.Command(cc, "-std=c99", "-o", prog, src, "-I", wd, "-L", dir, "-ljsonrepair", "-Wl,-rpath,"+dir)
+	if out, err := compile.CombinedOutput(); err != nil {
+		t.Fatalf("cc: %v\n%s", err, out)
+	}
+
+	out, err := exec.Command(prog, `{"a": 1}`, "{'employees':['John', 'Anna', ", "[1, 2,]").Output()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "0 {\"a\":1}\n0 {\"employees\":[\"John\",\"Anna\"]}\n0 [1,2]\n"
+	if string(out) != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
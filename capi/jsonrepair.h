@@ -0,0 +1,36 @@
+/*
+ * jsonrepair.h - C bindings of github.com/RealAlexandreAI/json-repair.
+ *
+ * Build the library from the capi directory with
+ *
+ *   go build -buildmode=c-shared -o libjsonrepair.so .
+ *   go build -buildmode=c-archive -o libjsonrepair.a .
+ *
+ * and link against it. All functions are safe to call from several threads.
+ */
+#ifndef JSONREPAIR_H
+#define JSONREPAIR_H
+
+#include <stddef.h>
+
+#ifdef __cplusplus
+extern "C" {
+#endif
+
+/*
+ * jsonrepair_repair repairs the len bytes at src, which need not be
+ * NUL-terminated. On success it returns 0 and stores the repaired document
+ * in *out and its length, without the terminating NUL, in *outlen. On
+ * failure it returns -1 and stores an error message there instead. Either
+ * way *out must be released with jsonrepair_free.
+ */
+int jsonrepair_repair(const char *src, size_t len, char **out, size_t *outlen);
+
+/* jsonrepair_free releases a string returned by jsonrepair_repair. */
+void jsonrepair_free(char *p);
+
+#ifdef __cplusplus
+}
+#endif
+
+#endif /* JSONREPAIR_H */
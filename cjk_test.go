@@ -0,0 +1,70 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_CJKPunctuation
+//
+//	Description:
+//	param t
+func Test_RepairJSON_CJKPunctuation(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   `{「名前」：「田中太郎」，「年齢」：30}`,
+			want: `{"名前":"田中太郎","年齢":30}`,
+		},
+		{
+			in:   `{"书名": 《三体》, "作者": 『刘慈欣』, "引用": 「他说『你好』」}`,
+			want: `{"书名":"三体","作者":"刘慈欣","引用":"他说『你好』"}`,
+		},
+		{
+			in:   `{"标签": 【"科幻", "小说"】, "评分": 【9、8、7】}`,
+			want: `{"标签":["科幻","小说"],"评分":[9,8,7]}`,
+		},
+		{
+			in:   `{"城市": ["北京"、"上海"、"广州"]}`,
+			want: `{"城市":["北京","上海","广州"]}`,
+		},
+		{
+			in:   `{"回答": "好的。", "状态": "完成"。}。`,
+			want: `{"回答":"好的。","状态":"完成"}`,
+		},
+		{
+			in:   `{"summary": "东、西、南、北。", "note": "「引号」在字符串里不变"}`,
+			want: `{"summary":"东、西、南、北。","note":"「引号」在字符串里不变"}`,
+		},
+		{
+			in:   `{"answer": true。, "list": [1, 2]。}`,
+			want: `{"answer":true,"list":[1,2]}`,
+		},
+		{
+			in:   "```json\n{\n  「result」: 【\n    {「id」: 1, 「name」: 「りんご」},\n    {「id」: 2, 「name」: 「みかん」}\n  】\n}\n```",
+			want: `{"result":[{"id":1,"name":"りんご"},{"id":2,"name":"みかん"}]}`,
+		},
+		{
+			in:   `{"a": 「x」、「y」}`,
+			want: `{"a":"「x」、「y」"}`,
+		},
+		{
+			in:   `{"a": 「x」、「y」, "b": 1、「c」：2, d: [「e」、「f」]}`,
+			want: `{"a":"「x」、「y」","b":1,"c":2,"d":["e","f"]}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+}
@@ -12,10 +12,11 @@ import (
 var version string
 
 var (
-	versionFlag bool
-	helpFlag    bool
-	file        string
-	input       string
+	versionFlag  bool
+	helpFlag     bool
+	file         string
+	input        string
+	outputFormat string
 )
 
 // init
@@ -26,6 +27,7 @@ func init() {
 	flag.BoolVar(&helpFlag, "h", false, "Print help")
 	flag.StringVar(&input, "i", "", "String input inline")
 	flag.StringVar(&file, "f", "", "File path")
+	flag.StringVar(&outputFormat, "output-format", "json", "Output format: json, json5 or hjson")
 }
 
 // printDefaults
@@ -33,6 +35,7 @@ func init() {
 //	@Description:
 func printDefaults() {
 	fmt.Println("Usage: jsonrepair <options>")
+	fmt.Println("       jsonrepair serve [--addr :8080] [--max-body-size bytes]")
 	fmt.Println("Options:")
 	flag.VisitAll(func(flag *flag.Flag) {
 		fmt.Println("\t-"+flag.Name, "\t", flag.Usage, "(Default "+flag.DefValue+")")
@@ -43,6 +46,13 @@ func printDefaults() {
 //
 //	@Description:
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		if err := serve(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 	fmt.Print(cliInner())
 }
 
@@ -65,14 +75,36 @@ func cliInner() string {
 
 	switch {
 	case input != "":
-		return jsonrepair.MustRepairJSON(input)
+		return repair(input)
 	case file != "":
 		fi, err := os.ReadFile(file)
 		if err != nil {
 			return fmt.Sprintf("[json-repair] invalid file path: %s", file)
 		}
-		return jsonrepair.MustRepairJSON(string(fi))
+		return repair(string(fi))
 	default:
 		return ""
 	}
 }
+
+// repair
+//
+//	Description: repairs src and renders it in the selected output format
+//	param src
+//	return string
+func repair(src string) string {
+	format := jsonrepair.OutputFormat(outputFormat)
+	if format == "" || format == jsonrepair.FormatJSON {
+		return jsonrepair.MustRepairJSON(src)
+	}
+
+	root, err := jsonrepair.ParseAST(src)
+	if err != nil {
+		return fmt.Sprintf("[json-repair] %s", err)
+	}
+	bs, err := jsonrepair.MarshalNode(root, format)
+	if err != nil {
+		return fmt.Sprintf("[json-repair] invalid output format: %s", outputFormat)
+	}
+	return string(bs)
+}
@@ -93,11 +93,28 @@ func Test_cliInner_i_f(t *testing.T) {
 	resetVars()
 }
 
+func Test_cliInner_output_format(t *testing.T) {
+
+	os.Args = append(os.Args, "--output-format", "json5")
+	os.Args = append(os.Args, "-i")
+	os.Args = append(os.Args, "{'employees':['John', 'Anna', ")
+
+	rst := cliInner()
+
+	if rst != "{\n  employees: [\n    'John',\n    'Anna',\n  ],\n}" {
+		t.Errorf("--output-format ut error.")
+	}
+
+	os.Args = os.Args[:len(os.Args)-4]
+	resetVars()
+}
+
 func resetVars() {
 	versionFlag = false
 	helpFlag = false
 	input = ""
 	file = ""
+	outputFormat = "json"
 }
 
 func writeToTemp(input string) string {
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/RealAlexandreAI/json-repair"
+)
+
+// serve
+//
+//	Description: runs the serve subcommand, an HTTP server exposing the
+//	repairer to other processes on the host. It fails on invalid flags and
+//	when the server cannot listen.
+//	param args
+//	return error
+func serve(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", ":8080", "Listen address")
+	maxBodySize := fs.Int64("max-body-size", 10<<20, "Request body size limit in bytes")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return fmt.Errorf("[json-repair] serve: %w", err)
+	}
+
+	srv := &http.Server{
+		Addr:              *addr,
+		Handler:           newServeMux(*maxBodySize),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		WriteTimeout:      time.Minute,
+		IdleTimeout:       2 * time.Minute,
+	}
+	if err := srv.ListenAndServe(); err != nil {
+		return fmt.Errorf("[json-repair] serve: %w", err)
+	}
+	return nil
+}
+
+// newServeMux
+//
+//	Description: routes POST /repair, POST /extract and GET /healthz
+//	param maxBodySize
+//	return http.Handler
+func newServeMux(maxBodySize int64) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			methodNotAllowed(w, http.MethodGet)
+			return
+		}
+		writeJSON(w, http.StatusOK, []byte(`{"status":"ok"}`))
+	})
+	mux.HandleFunc("/repair", func(w http.ResponseWriter, r *http.Request) {
+		handleRepair(w, r, maxBodySize, false)
+	})
+	mux.HandleFunc("/extract", func(w http.ResponseWriter, r *http.Request) {
+		handleRepair(w, r, maxBodySize, true)
+	})
+	return mux
+}
+
+// repairEntry is the JSON form of jsonrepair.RepairEntry.
+type repairEntry struct {
+	Flag       jsonrepair.RepairFlag `json:"flag"`
+	Path       string                `json:"path"`
+	Key        bool                  `json:"key,omitempty"`
+	Start      int                   `json:"start"`
+	End        int                   `json:"end"`
+	Confidence float64               `json:"confidence"`
+}
+
+// repairResponse is the body of /repair and /extract when report is set.
+type repairResponse struct {
+	JSON           json.RawMessage `json:"json"`
+	Repaired       bool            `json:"repaired"`
+	Confidence     float64         `json:"confidence"`
+	Repairs        []repairEntry   `json:"repairs"`
+	Truncated      bool            `json:"truncated"`
+	AutoClosed     []string        `json:"auto_closed"`
+	TruncatedToken bool            `json:"truncated_token"`
+	EncodedLayers  int             `json:"encoded_layers"`
+}
+
+// handleRepair repairs the request body. The response is the repaired
+// document, or a repairResponse when the report query parameter is true.
+// With extract set, the document is taken from the body with the options
+// of extractOptions, and only an object or array is accepted; text without
+// one is answered with 422.
+func handleRepair(w http.ResponseWriter, r *http.Request, maxBodySize int64, extract bool) {
+	if r.Method != http.MethodPost {
+		methodNotAllowed(w, http.MethodPost)
+		return
+	}
+	withReport := false
+	if v := r.URL.Query().Get("report"); v != "" {
+		var err error
+		if withReport, err = strconv.ParseBool(v); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid report parameter: "+v)
+			return
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, "request body too large")
+			return
+		}
+		writeError(w, http.StatusBadRequest, "cannot read request body")
+		return
+	}
+
+	var opts []jsonrepair.Option
+	if extract {
+		if opts, err = extractOptions(r.URL.Query()); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	var dst string
+	var report *jsonrepair.Report
+	if withReport {
+		dst, report, err = jsonrepair.RepairJSONWithReport(string(body), opts...)
+	} else {
+		dst, err = jsonrepair.RepairJSON(string(body), opts...)
+	}
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if extract && (dst == "" || (dst[0] != '{' && dst[0] != '[')) {
+		writeError(w, http.StatusUnprocessableEntity, "no JSON object or array found")
+		return
+	}
+	if !withReport {
+		writeJSON(w, http.StatusOK, []byte(dst))
+		return
+	}
+
+	resp := repairResponse{
+		JSON:           json.RawMessage(dst),
+		Repaired:       report.Repaired(),
+		Confidence:     report.Confidence,
+		Repairs:        []repairEntry{},
+		Truncated:      report.Truncated,
+		AutoClosed:     report.AutoClosed,
+		TruncatedToken: report.TruncatedToken,
+		EncodedLayers:  report.EncodedLayers,
+	}
+	for _, e := range report.Repairs {
+		resp.Repairs = append(resp.Repairs, repairEntry(e))
+	}
+	bs, err := json.Marshal(resp)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, bs)
+}
+
+// defaultExtractTags are the tags /extract takes the document from unless
+// the request names its own.
+var defaultExtractTags = []string{"json", "answer", "output"}
+
+// fenceSelections maps the fence query parameter of /extract to the fence
+// selection.
+var fenceSelections = map[string]jsonrepair.FenceSelection{
+	"":        jsonrepair.FenceLast,
+	"last":    jsonrepair.FenceLast,
+	"first":   jsonrepair.FenceFirst,
+	"largest": jsonrepair.FenceLargest,
+	"all":     jsonrepair.FenceAll,
+}
+
+// extractOptions returns the extraction options of an /extract request from
+// its query: start and end for markers around the document, tag (which may
+// repeat) for the tags holding it, and fence for the code fence to take when
+// there are several (first, last, largest or all; last by default).
+func extractOptions(q url.Values) ([]jsonrepair.Option, error) {
+	var opts []jsonrepair.Option
+	start, end := q.Get("start"), q.Get("end")
+	if (start == "") != (end == "") {
+		return nil, errors.New("start and end must be given together")
+	}
+	if start != "" {
+		opts = append(opts, jsonrepair.WithExtractMarkers(start, end))
+	}
+
+	tags := q["tag"]
+	if len(tags) == 0 {
+		tags = defaultExtractTags
+	}
+	opts = append(opts, jsonrepair.WithExtractTags(tags...))
+
+	selection, ok := fenceSelections[q.Get("fence")]
+	if !ok {
+		return nil, fmt.Errorf("invalid fence parameter: %s", q.Get("fence"))
+	}
+	return append(opts, jsonrepair.WithFenceSelection(selection)), nil
+}
+
+// methodNotAllowed answers a request with an unsupported method.
+func methodNotAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+}
+
+// writeError answers with {"error": msg}.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	bs, _ := json.Marshal(map[string]string{"error": msg})
+	writeJSON(w, status, bs)
+}
+
+// writeJSON answers with the JSON document bs.
+func writeJSON(w http.ResponseWriter, status int, bs []byte) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(bs)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func Test_serve(t *testing.T) {
+	tests := []struct {
+		method     string
+		target     string
+		body       string
+		wantStatus int
+		want       string
+	}{
+		{
+			method:     http.MethodGet,
+			target:     "/healthz",
+			wantStatus: http.StatusOK,
+			want:       `{"status":"ok"}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/repair",
+			body:       "{'employees':['John', 'Anna', ",
+			wantStatus: http.StatusOK,
+			want:       `{"employees":["John","Anna"]}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/repair?report=1",
+			body:       `[1, 2, 3,]`,
+			wantStatus: http.StatusOK,
+			want: `{"json":[1,2,3],"repaired":true,"confidence":0.99,` +
+				`"repairs":[{"flag":"trailing_comma","path":"","start":0,"end":10,"confidence":0.99}],` +
+				`"truncated":false,"auto_closed":null,"truncated_token":false,"encoded_layers":0}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/extract",
+			body:       "Sure! Here it is:\n```json\n{\"a\": 1,}\n```\nAnything else?",
+			wantStatus: http.StatusOK,
+			want:       `{"a":1}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/extract",
+			body:       "Sorry, I cannot help with that.",
+			wantStatus: http.StatusUnprocessableEntity,
+			want:       `{"error":"no JSON object or array found"}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/extract",
+			body:       `<thinking>maybe {"x": 1}</thinking> <answer>{'a': 1}</answer>`,
+			wantStatus: http.StatusOK,
+			want:       `{"a":1}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/extract?start=BEGIN&end=END",
+			body:       `draft {"x": 1} BEGIN{"a": [1,]}END`,
+			wantStatus: http.StatusOK,
+			want:       `{"a":[1]}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/extract?fence=first",
+			body:       "```json\n[1]\n```\nor\n```json\n[2]\n```",
+			wantStatus: http.StatusOK,
+			want:       `[1]`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/extract?fence=middle",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			want:       `{"error":"invalid fence parameter: middle"}`,
+		},
+		{
+			method:     http.MethodGet,
+			target:     "/repair",
+			wantStatus: http.StatusMethodNotAllowed,
+			want:       `{"error":"method not allowed"}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/repair?report=maybe",
+			body:       `{}`,
+			wantStatus: http.StatusBadRequest,
+			want:       `{"error":"invalid report parameter: maybe"}`,
+		},
+		{
+			method:     http.MethodPost,
+			target:     "/repair",
+			body:       `{"a": "` + strings.Repeat("x", 64) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+			want:       `{"error":"request body too large"}`,
+		},
+	}
+
+	handler := newServeMux(64)
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !json.Valid(rec.Body.Bytes()) || !jsonStringsEqual(rec.Body.String(), tt.want) {
+				t.Errorf("body = %s, want %s", rec.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func Test_serve_error(t *testing.T) {
+	for _, args := range [][]string{{"--no-such-flag"}, {"--addr", "localhost:99999"}} {
+		if err := serve(args); err == nil {
+			t.Errorf("serve(%q) error = nil", args)
+		}
+	}
+}
@@ -0,0 +1,244 @@
+package jsonrepair
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Decoder
+//
+//	Description: reads JSON values from a stream like encoding/json.Decoder,
+//	repairing each value before decoding it. Values are split at the bracket
+//	that closes them; a value that is never closed runs until a line starting
+//	with '{' or '[', or the end of the stream. Commas, code fence lines and
+//	prose words between values are skipped.
+type Decoder struct {
+	r    *bufio.Reader
+	opts []Option
+
+	useNumber             bool
+	disallowUnknownFields bool
+}
+
+// NewDecoder
+//
+//	Description: returns a Decoder reading from r. opts apply to the repair of
+//	every value.
+//	param r
+//	param opts
+//	return *Decoder
+func NewDecoder(r io.Reader, opts ...Option) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), opts: opts}
+}
+
+// UseNumber
+//
+//	Description: decodes numbers into an interface{} as a json.Number
+//	instead of a float64, see encoding/json.Decoder.UseNumber
+//	receiver d
+func (d *Decoder) UseNumber() {
+	d.useNumber = true
+}
+
+// DisallowUnknownFields
+//
+//	Description: makes Decode return an error when a struct destination has
+//	no field for a key, see encoding/json.Decoder.DisallowUnknownFields
+//	receiver d
+func (d *Decoder) DisallowUnknownFields() {
+	d.disallowUnknownFields = true
+}
+
+// More
+//
+//	Description: reports whether there is another value in the stream
+//	receiver d
+//	return bool
+func (d *Decoder) More() bool {
+	return d.skipSeparators() == nil
+}
+
+// Decode
+//
+//	Description: reads the next value from the stream, repairs it and stores
+//	it in the value pointed to by v. It returns io.EOF at the end of the
+//	stream.
+//	receiver d
+//	param v
+//	return error
+func (d *Decoder) Decode(v any) error {
+	raw, err := d.readValue()
+	if err != nil {
+		return err
+	}
+
+	dst, err := RepairJSON(string(raw), d.opts...)
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(strings.NewReader(dst))
+	if d.useNumber {
+		dec.UseNumber()
+	}
+	if d.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
+
+// skipSeparators skips whitespace, commas and code fence lines before the
+// next value. It returns io.EOF if the stream has no more values.
+func (d *Decoder) skipSeparators() error {
+	for {
+		c, err := d.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n' || c == ',':
+		case c == '`':
+			if _, err := d.r.ReadString('\n'); err != nil {
+				return err
+			}
+		default:
+			return d.r.UnreadByte()
+		}
+	}
+}
+
+// readValue returns the raw bytes of the next value in the stream. Words
+// before a value that are not JSON scalars, such as "Sure! Here it is:",
+// are prose and skipped.
+func (d *Decoder) readValue() ([]byte, error) {
+	for {
+		if err := d.skipSeparators(); err != nil {
+			return nil, err
+		}
+		next, err := d.r.Peek(1)
+		if err != nil {
+			return nil, err
+		}
+		if strings.IndexByte("{[\"'", next[0]) != -1 {
+			return d.readQuotedOrContainer()
+		}
+
+		word, err := d.readWord()
+		if err != nil {
+			return nil, err
+		}
+		if isScalarWord(word) {
+			return word, nil
+		}
+	}
+}
+
+// readWord returns the bytes up to the next whitespace, comma or opening
+// bracket.
+func (d *Decoder) readWord() ([]byte, error) {
+	var buf bytes.Buffer
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF {
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.IndexByte(" \t\r\n,{[", c) != -1 {
+			return buf.Bytes(), d.r.UnreadByte()
+		}
+		buf.WriteByte(c)
+	}
+}
+
+// isScalarWord reports whether word is a top-level number or literal
+// rather than prose.
+func isScalarWord(word []byte) bool {
+	switch string(word) {
+	case "True", "False", "None":
+		return true
+	}
+	return json.Valid(word)
+}
+
+// readQuotedOrContainer returns the raw bytes of the string, object or
+// array starting at the next byte. A single quote starts a string only
+// where a value or key can start, so apostrophes in unquoted text do not.
+func (d *Decoder) readQuotedOrContainer() ([]byte, error) {
+	var buf bytes.Buffer
+	var stack []byte
+	var quote byte
+	last := byte('[')
+
+	for {
+		c, err := d.r.ReadByte()
+		if err == io.EOF {
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if quote != 0 {
+			buf.WriteByte(c)
+			switch c {
+			case '\\':
+				if c, err = d.r.ReadByte(); err == nil {
+					buf.WriteByte(c)
+				}
+			case quote:
+				quote = 0
+				last = c
+			case '\n':
+				if d.startsValue() {
+					return buf.Bytes(), nil
+				}
+			}
+			if len(stack) == 0 && quote == 0 {
+				return buf.Bytes(), nil
+			}
+			continue
+		}
+
+		buf.WriteByte(c)
+		switch c {
+		case '"':
+			quote = c
+		case '\'':
+			if strings.IndexByte("{[,:", last) != -1 {
+				quote = c
+			}
+		case '{', '[':
+			stack = append(stack, c)
+		case '}', ']':
+			open := byte('{')
+			if c == ']' {
+				open = '['
+			}
+			if i := bytes.LastIndexByte(stack, open); i >= 0 {
+				stack = stack[:i]
+				if len(stack) == 0 {
+					return buf.Bytes(), nil
+				}
+			}
+		case '\n':
+			if d.startsValue() {
+				return buf.Bytes(), nil
+			}
+		}
+		if !isASCIISpace(rune(c)) {
+			last = c
+		}
+	}
+}
+
+// startsValue reports whether the next byte, at the start of a line, opens
+// a new object or array, ending a value that was never closed.
+func (d *Decoder) startsValue() bool {
+	next, err := d.r.Peek(1)
+	return err == nil && (next[0] == '{' || next[0] == '[')
+}
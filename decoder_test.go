@@ -0,0 +1,99 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test_Decoder
+//
+//	Description:
+//	param t
+func Test_Decoder(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []any
+	}{
+		{
+			in:   "{\"a\": 1}\n{\"b\": [1, 2,]}\n",
+			want: []any{map[string]any{"a": 1.0}, map[string]any{"b": []any{1.0, 2.0}}},
+		},
+		{
+			in:   "{'a': True}{\"b\": 'x'} [1, 2] \"s\" 3 true",
+			want: []any{map[string]any{"a": true}, map[string]any{"b": "x"}, []any{1.0, 2.0}, "s", 3.0, true},
+		},
+		{
+			in:   "{\"a\": [1, 2}\n{\"b\": \"open\n{\"c\": 3}",
+			want: []any{map[string]any{"a": []any{1.0, 2.0}}, map[string]any{"b": "open"}, map[string]any{"c": 3.0}},
+		},
+		{
+			in:   "```json\n{\"text\": \"a } and ] in a string\"}\n```\n",
+			want: []any{map[string]any{"text": "a } and ] in a string"}},
+		},
+		{
+			in:   "[{\"a\": 1}, {\"b\": 2}], [3",
+			want: []any{[]any{map[string]any{"a": 1.0}, map[string]any{"b": 2.0}}, []any{3.0}},
+		},
+		{
+			in:   "{'a': '}', 'b': 2} {'c': \"it's ] here\"}",
+			want: []any{map[string]any{"a": "}", "b": 2.0}, map[string]any{"c": "it's ] here"}},
+		},
+		{
+			in:   "Sure! Here's the data: {\"a\": 1} and the next one:\n[2]",
+			want: []any{map[string]any{"a": 1.0}, []any{2.0}},
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			dec := NewDecoder(strings.NewReader(tt.in))
+			var got []any
+			for dec.More() {
+				var v any
+				if err := dec.Decode(&v); err != nil {
+					t.Fatalf("Decode() error = %v", err)
+				}
+				got = append(got, v)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Decode() got = %#v, want %#v", got, tt.want)
+			}
+
+			var v any
+			if err := dec.Decode(&v); !errors.Is(err, io.EOF) {
+				t.Errorf("Decode() at the end error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
+
+// Test_Decoder_Options
+//
+//	Description:
+//	param t
+func Test_Decoder_Options(t *testing.T) {
+	dec := NewDecoder(strings.NewReader(`{'n': 12345678901234567890, 'ok': True}`), WithPythonLiterals())
+	dec.UseNumber()
+	var v map[string]any
+	if err := dec.Decode(&v); err != nil {
+		t.Fatal(err)
+	}
+	if v["n"] != json.Number("12345678901234567890") || v["ok"] != true {
+		t.Errorf("Decode() got = %v", v)
+	}
+
+	type payload struct {
+		A int `json:"a"`
+	}
+	dec = NewDecoder(strings.NewReader(`{"a": 1, "b": 2`))
+	dec.DisallowUnknownFields()
+	var p payload
+	if err := dec.Decode(&p); err == nil {
+		t.Errorf("Decode() expected an unknown field error")
+	}
+}
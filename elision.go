@@ -0,0 +1,141 @@
+package jsonrepair
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrElision is returned under ElisionError when the model left out
+// elements with a marker such as "..." or "// more items".
+var ErrElision = errors.New("jsonrepair: input elides elements")
+
+// ElisionPolicy
+//
+//	Description: what to do with elision markers (..., …, [...], {...} or a
+//	"// more items" comment) written in place of elements, see
+//	WithElisionPolicy
+type ElisionPolicy int
+
+const (
+	// ElisionDrop drops the marker (default).
+	ElisionDrop ElisionPolicy = iota
+	// ElisionKeep keeps the marker as a string element. Markers in key
+	// position and comments are still dropped, since they have no value.
+	ElisionKeep
+	// ElisionError fails with ErrElision.
+	ElisionError
+)
+
+// elisionMarkerLen returns the length of the elision marker at p.index, or 0.
+// A marker is a bare run of dots or '…', or the same inside [] or {},
+// followed by the end of the element. Quoted strings such as "..." are
+// values and never markers.
+func (p *JSONParser) elisionMarkerLen() int {
+	s := p.container[p.index:]
+
+	n := 0
+	switch {
+	case strings.HasPrefix(s, "[") || strings.HasPrefix(s, "{"):
+		end := strings.IndexByte(s, "]}"[strings.IndexByte("[{", s[0])])
+		if end > 0 && ellipsisLen(strings.TrimSpace(s[1:end])) == len(strings.TrimSpace(s[1:end])) &&
+			strings.TrimSpace(s[1:end]) != "" {
+			n = end + 1
+		}
+	default:
+		n = ellipsisLen(s)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	rest := strings.TrimLeft(s[n:], " \t\r\n")
+	if rest != "" && strings.IndexByte(",]}", rest[0]) == -1 {
+		return 0
+	}
+	return n
+}
+
+// ellipsisLen returns the length of the run of at least two dots (which may
+// be spaced out) or of '…' at the start of s, or 0.
+func ellipsisLen(s string) int {
+	dots, n := 0, 0
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '.':
+			dots++
+		case r == '…':
+			dots += 3
+		case r == ' ' && dots > 0:
+			i += size
+			continue
+		default:
+			i = len(s)
+			continue
+		}
+		i += size
+		n = i
+	}
+	if dots < 2 {
+		return 0
+	}
+	return n
+}
+
+// isElisionComment reports whether a comment stands for elided elements,
+// e.g. "// more items", "/* ... */" or "# etc". Only the whole comment
+// counts, so "// remaining budget" or "// more details below" do not.
+func isElisionComment(c string) bool {
+	c = strings.TrimPrefix(strings.TrimPrefix(c, "//"), "#")
+	c = strings.TrimSuffix(strings.TrimPrefix(c, "/*"), "*/")
+	c = strings.ToLower(strings.TrimSpace(c))
+
+	if strings.Contains(c, "...") || strings.Contains(c, "…") {
+		return true
+	}
+	switch strings.TrimRight(c, ".:;! ") {
+	case "more", "and more", "more items", "more elements", "more entries", "more fields",
+		"and more items", "and so on", "and so forth", "etc", "omitted", "rest omitted":
+		return true
+	}
+	return false
+}
+
+// parseElision returns the elision marker at p.index as a string node
+// flagged RepairEllipsis; parseArray and parseObject apply the policy.
+func (p *JSONParser) parseElision(size int) *Node {
+	n := newScalarNode(p.container[p.index:p.index+size], p.index, p.index+size)
+	n.addRepair(RepairEllipsis)
+	p.index += size
+	return n
+}
+
+// isElision reports whether n is an elision marker to drop.
+func (p *JSONParser) isElision(n *Node) bool {
+	return n.Kind == StringNode && n.HasRepair(RepairEllipsis) && p.opts.elision != ElisionKeep
+}
+
+// markElidedComments flags containers holding an elision comment between or
+// after their elements, and reports whether the tree has any elision.
+func markElidedComments(root *Node) bool {
+	elided := false
+	Walk(root, func(_ string, n *Node) bool {
+		comments := slices.Clone(n.InnerComments)
+		for _, c := range n.Children {
+			comments = append(comments, c.Comments...)
+		}
+		for _, m := range n.Members {
+			comments = append(comments, m.Value.Comments...)
+		}
+		for _, c := range comments {
+			if isElisionComment(c) {
+				n.addRepair(RepairEllipsis)
+			}
+		}
+		elided = elided || n.HasRepair(RepairEllipsis)
+		return true
+	})
+	return elided
+}
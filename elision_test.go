@@ -0,0 +1,100 @@
+package jsonrepair
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_Elision
+//
+//	Description:
+//	param t
+func Test_RepairJSON_Elision(t *testing.T) {
+	tests := []struct {
+		in     string
+		policy ElisionPolicy
+		want   string
+		paths  []string
+	}{
+		{
+			in:    `[1, 2, ...]`,
+			want:  `[1,2]`,
+			paths: []string{""},
+		},
+		{
+			in:    `{"ids": [1, …, 9], "more": [{"a": 1}, {...}], "nested": [[1], [...]]}`,
+			want:  `{"ids":[1,9],"more":[{"a":1}],"nested":[[1]]}`,
+			paths: []string{"/ids", "/more", "/nested"},
+		},
+		{
+			in:    `{"tags": ["a", "b", "etc"], "quoted": ["...", "b"], "dots": [1, 2, . . .]`,
+			want:  `{"tags":["a","b","etc"],"quoted":["...","b"],"dots":[1,2]}`,
+			paths: []string{"/dots"},
+		},
+		{
+			in:    `{"a": 1, "b": ..., ...}`,
+			want:  `{"a":1}`,
+			paths: []string{""},
+		},
+		{
+			in:    "{\"items\": [\n  {\"id\": 1},\n  // more items\n]}",
+			want:  `{"items":[{"id":1}]}`,
+			paths: []string{"/items"},
+		},
+		{
+			in:     `{"ids": [1, 2, ...], "etc": "etc"`,
+			policy: ElisionKeep,
+			want:   `{"ids":[1,2,"..."],"etc":"etc"}`,
+			paths:  []string{"/ids/2"},
+		},
+		{
+			in:   `{"text": "wait...", "dots": [1.5, ".."]}`,
+			want: `{"text":"wait...","dots":[1.5,".."]}`,
+		},
+		{
+			in:   "{ // remaining budget\n \"budget\": 5, // more details below\n \"other\": [1] /* other fields */ }",
+			want: `{"budget":5,"other":[1]}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, report, err := RepairJSONWithReport(tt.in, WithElisionPolicy(tt.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSONWithReport() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+
+			var paths []string
+			for _, r := range report.Repairs {
+				if r.Flag == RepairEllipsis {
+					paths = append(paths, r.Path)
+				}
+			}
+			if len(paths) != len(tt.paths) {
+				t.Fatalf("ellipsis repairs at %v, want %v", paths, tt.paths)
+			}
+			for i := range paths {
+				if paths[i] != tt.paths[i] {
+					t.Errorf("ellipsis repairs at %v, want %v", paths, tt.paths)
+				}
+			}
+		})
+	}
+
+	for _, in := range []string{`[1, 2, ...]`, "[1, // and so on\n]", "[1, 2 // more items\n]"} {
+		if _, err := RepairJSON(in, WithElisionPolicy(ElisionError)); !errors.Is(err, ErrElision) {
+			t.Errorf("RepairJSON(%q) error = %v, want %v", in, err, ErrElision)
+		}
+		if got := MustRepairJSON(in, WithElisionPolicy(ElisionError)); got != "" {
+			t.Errorf("MustRepairJSON(%q) = %v, want empty", in, got)
+		}
+	}
+	in := "{ // remaining budget\n \"budget\": 5, }"
+	if got, err := RepairJSON(in, WithElisionPolicy(ElisionError)); err != nil || got != `{"budget":5}` {
+		t.Errorf("RepairJSON(%q) = %v, %v, want {\"budget\":5}", in, got, err)
+	}
+}
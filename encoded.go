@@ -0,0 +1,143 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// maxEncodedLayers bounds how many layers unwrapEncoded removes.
+const maxEncodedLayers = 8
+
+// unwrapEncoded removes the layers of string encoding around a document and
+// returns the inner document and the number of layers removed. A layer is
+// either a JSON string literal holding the document or the next layer
+// ("{\"a\":1}", "\"{\\\"a\\\":1}\"") or the document with all of its quotes
+// backslash-escaped ({\"a\":1}). Layers that are cut off, as in a truncated
+// document, are unwrapped too.
+//
+// The deepest layer that is a valid JSON document is returned. If there is
+// none and s is not valid JSON itself, the deepest layer that repairs into an
+// object or array with no less confidence than s is; so a string such as
+// "[INFO] started" stays a string.
+func unwrapEncoded(s string) (string, int) {
+	var layers []string
+	cur := s
+layers:
+	for len(layers) < maxEncodedLayers {
+		t := strings.TrimSpace(cur)
+		if len(t) < 2 {
+			break
+		}
+
+		switch {
+		case t[0] == '"':
+			inner := t[1:]
+			if strings.HasSuffix(inner, `"`) && !isEscapedAt(inner, len(inner)-1) {
+				inner = inner[:len(inner)-1]
+			}
+			cur = unescapeLayer(inner)
+			if !startsDocument(cur) && !strings.HasPrefix(strings.TrimSpace(cur), `"`) {
+				break layers
+			}
+		case startsDocument(t) && isEscapedLayer(t):
+			cur = unescapeLayer(t)
+		default:
+			break layers
+		}
+		layers = append(layers, cur)
+	}
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		if startsDocument(layers[i]) && json.Valid([]byte(layers[i])) {
+			return layers[i], i + 1
+		}
+	}
+	if len(layers) == 0 || json.Valid([]byte(s)) {
+		return s, 0
+	}
+	base, _ := repairedConfidence(s)
+	for i := len(layers) - 1; i >= 0; i-- {
+		if !startsDocument(layers[i]) {
+			continue
+		}
+		if c, container := repairedConfidence(layers[i]); container && c >= base {
+			return layers[i], i + 1
+		}
+	}
+	return s, 0
+}
+
+// repairedConfidence repairs s with the default options and returns the
+// confidence of the result and whether it is an object or array.
+func repairedConfidence(s string) (float64, bool) {
+	root := newJSONParser(s, newOptions(nil)).parseDocument()
+	return NewReport(root).Confidence, root.Kind == ObjectNode || root.Kind == ArrayNode
+}
+
+// startsDocument reports whether s starts like a JSON object or array.
+func startsDocument(s string) bool {
+	s = strings.TrimSpace(s)
+	return s != "" && (s[0] == '{' || s[0] == '[')
+}
+
+// isEscapedLayer reports whether every quote in s is backslash-escaped and
+// there is at least one.
+func isEscapedLayer(s string) bool {
+	quotes := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '"' {
+			continue
+		}
+		if !isEscapedAt(s, i) {
+			return false
+		}
+		quotes++
+	}
+	return quotes > 0
+}
+
+// isEscapedAt reports whether s[i] is preceded by an odd number of
+// backslashes.
+func isEscapedAt(s string, i int) bool {
+	n := 0
+	for j := i - 1; j >= 0 && s[j] == '\\'; j-- {
+		n++
+	}
+	return n%2 == 1
+}
+
+// unescapeLayer decodes the JSON string escapes in s, keeping unknown
+// escapes as they are.
+func unescapeLayer(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '\\' || i+1 == len(s) {
+			sb.WriteByte(c)
+			continue
+		}
+		next := s[i+1]
+		if ce, ok := simpleEscapes[next]; ok {
+			sb.WriteByte(ce)
+			i++
+			continue
+		}
+		if next == 'u' && i+6 <= len(s) {
+			if r, err := strconv.ParseUint(s[i+2:i+6], 16, 32); err == nil {
+				if utf16.IsSurrogate(rune(r)) {
+					// keep surrogate halves escaped for parseEscape to pair
+					sb.WriteString(s[i : i+6])
+				} else {
+					sb.WriteRune(rune(r))
+				}
+				i += 5
+				continue
+			}
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
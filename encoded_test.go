@@ -0,0 +1,98 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_UnwrapEncoded
+//
+//	Description:
+//	param t
+func Test_RepairJSON_UnwrapEncoded(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   string
+		layers int
+	}{
+		{
+			in:     `"{\"a\":1,\"b\":[\"x\"]}"`,
+			want:   `{"a":1,"b":["x"]}`,
+			layers: 1,
+		},
+		{
+			in:     `{\"a\":1,\"url\":\"http://x.io/\"}`,
+			want:   `{"a":1,"url":"http://x.io/"}`,
+			layers: 1,
+		},
+		{
+			in:     `"{\\\"a\\\": \\\"say \\\\\\\"hi\\\\\\\"\\\"}"`,
+			want:   `{"a":"say \"hi\""}`,
+			layers: 2,
+		},
+		{
+			in:     `"{\"name\": \"Zoë\", \"emoji\": \"😀\", \"list\": [1, 2`,
+			want:   `{"name":"Zoë","emoji":"😀","list":[1,2]}`,
+			layers: 1,
+		},
+		{
+			in:     `"just a string"`,
+			want:   `"just a string"`,
+			layers: 0,
+		},
+		{
+			in:     `{"a": "\"quoted\""}`,
+			want:   `{"a":"\"quoted\""}`,
+			layers: 0,
+		},
+		{
+			in:     `"\"{\\\"a\\\":1}\""`,
+			want:   `{"a":1}`,
+			layers: 2,
+		},
+		{
+			in:     `"[INFO] started"`,
+			want:   `"[INFO] started"`,
+			layers: 0,
+		},
+		{
+			in:     "\ufeff\"{\\\"a\\\":1}\"",
+			want:   `{"a":1}`,
+			layers: 1,
+		},
+		{
+			in:     "\xff\xfe\"\x00{\x00\\\x00\"\x00a\x00\\\x00\"\x00:\x001\x00}\x00\"\x00",
+			want:   `{"a":1}`,
+			layers: 1,
+		},
+		{
+			in:     "Here:\n```json\n\"{\\\"a\\\": \\\"{\\\\\\\"b\\\\\\\":1}\\\"}\"\n```\n",
+			want:   `{"a":"{\"b\":1}"}`,
+			layers: 1,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, WithUnwrapEncoded())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+
+			_, report, err := RepairJSONWithReport(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.EncodedLayers != tt.layers {
+				t.Errorf("EncodedLayers = %d, want %d", report.EncodedLayers, tt.layers)
+			}
+		})
+	}
+
+	if got, _ := RepairJSON(`"{\"a\":1}"`); got != `"{\"a\":1}"` {
+		t.Errorf("RepairJSON() without WithUnwrapEncoded = %v", got)
+	}
+}
@@ -0,0 +1,135 @@
+package jsonrepair
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 is returned for input with invalid UTF-8 bytes under
+// InvalidUTF8Error.
+var ErrInvalidUTF8 = errors.New("jsonrepair: input is not valid UTF-8")
+
+const utf8BOM = "\xef\xbb\xbf"
+
+// InvalidUTF8Policy
+//
+//	Description: what to do with bytes that are not valid UTF-8, see
+//	WithInvalidUTF8
+type InvalidUTF8Policy int
+
+const (
+	// InvalidUTF8Keep passes invalid bytes to the parser unchanged (default).
+	// Latin-1 and Windows-1252 input is not detected; the fallback is opt-in
+	// with InvalidUTF8Windows1252.
+	InvalidUTF8Keep InvalidUTF8Policy = iota
+	// InvalidUTF8Replace replaces each invalid byte with U+FFFD.
+	InvalidUTF8Replace
+	// InvalidUTF8Windows1252 decodes each invalid byte as Windows-1252, which
+	// also covers Latin-1 input.
+	InvalidUTF8Windows1252
+	// InvalidUTF8Error fails with ErrInvalidUTF8.
+	InvalidUTF8Error
+)
+
+// windows1252 maps the bytes 0x80-0x9F of Windows-1252; the bytes it leaves
+// undefined map to the C1 control with the same value, as in WHATWG.
+var windows1252 = [32]rune{
+	'€', 0x81, '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', 0x8d, 'Ž', 0x8f,
+	0x90, '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', 0x9d, 'ž', 'Ÿ',
+}
+
+// decodeInput turns src into UTF-8 text before normalizeInput:
+//
+//   - a UTF-8 byte order mark is dropped
+//   - UTF-16 input, detected by its byte order mark or by the NUL bytes of
+//     ASCII characters, is transcoded
+//   - invalid UTF-8 bytes are handled according to the InvalidUTF8Policy
+//
+// The returned flags record which of the steps changed the input.
+func decodeInput(src string, o *options) (string, []RepairFlag, error) {
+	var flags []RepairFlag
+
+	if s, ok := decodeUTF16(src); ok {
+		flags = append(flags, RepairEncoding)
+		src = s
+	} else if strings.HasPrefix(src, utf8BOM) {
+		flags = append(flags, RepairEncoding)
+		src = src[len(utf8BOM):]
+	}
+
+	if o.invalidUTF8 == InvalidUTF8Keep || utf8.ValidString(src) {
+		return src, flags, nil
+	}
+
+	switch o.invalidUTF8 {
+	case InvalidUTF8Error:
+		return "", nil, ErrInvalidUTF8
+	case InvalidUTF8Windows1252:
+		flags = append(flags, RepairEncoding)
+	default:
+		flags = append(flags, RepairInvalidUTF8)
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(src))
+	for i := 0; i < len(src); {
+		r, size := utf8.DecodeRuneInString(src[i:])
+		if r == utf8.RuneError && size == 1 && o.invalidUTF8 == InvalidUTF8Windows1252 {
+			// invalid bytes are always >= 0x80
+			r = rune(src[i])
+			if r < 0xa0 {
+				r = windows1252[r-0x80]
+			}
+		}
+		sb.WriteRune(r)
+		i += size
+	}
+	return sb.String(), flags, nil
+}
+
+// decodeUTF16 transcodes src from UTF-16 when it starts with a UTF-16 byte
+// order mark, or when it has no mark but every other one of its first bytes
+// is NUL, as with ASCII text in UTF-16. A trailing odd byte becomes U+FFFD.
+func decodeUTF16(src string) (string, bool) {
+	var bigEndian bool
+	switch {
+	case strings.HasPrefix(src, "\xff\xfe"):
+		src = src[2:]
+	case strings.HasPrefix(src, "\xfe\xff"):
+		src, bigEndian = src[2:], true
+	case len(src) >= 2 && src[0] != 0 && src[1] == 0 && isUTF16ASCII(src, 1):
+	case len(src) >= 2 && src[0] == 0 && src[1] != 0 && isUTF16ASCII(src, 0):
+		bigEndian = true
+	default:
+		return "", false
+	}
+
+	units := make([]uint16, len(src)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(src[2*i])<<8 | uint16(src[2*i+1])
+		} else {
+			units[i] = uint16(src[2*i+1])<<8 | uint16(src[2*i])
+		}
+	}
+	dst := string(utf16.Decode(units))
+	if len(src)%2 == 1 {
+		// half a code unit, as in truncated input
+		dst += string(utf8.RuneError)
+	}
+	return dst, true
+}
+
+// isUTF16ASCII reports whether the bytes at the given parity are NUL in the
+// first code units of src.
+func isUTF16ASCII(src string, parity int) bool {
+	n := min(len(src)/2, 64)
+	for i := 0; i < n; i++ {
+		if src[2*i+parity] != 0 {
+			return false
+		}
+	}
+	return true
+}
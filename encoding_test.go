@@ -0,0 +1,109 @@
+package jsonrepair
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+	"unicode/utf16"
+)
+
+// utf16Bytes encodes s as UTF-16 with the given byte order.
+func utf16Bytes(s string, bigEndian bool) string {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		if bigEndian {
+			b = append(b, byte(u>>8), byte(u))
+		} else {
+			b = append(b, byte(u), byte(u>>8))
+		}
+	}
+	return string(b)
+}
+
+// Test_RepairJSON_Encoding
+//
+//	Description:
+//	param t
+func Test_RepairJSON_Encoding(t *testing.T) {
+	tests := []struct {
+		in       string
+		opts     []Option
+		want     string
+		wantFlag RepairFlag
+	}{
+		{
+			in:       "\xef\xbb\xbf{\"a\": 1}",
+			want:     `{"a":1}`,
+			wantFlag: RepairEncoding,
+		},
+		{
+			in:       "\xff\xfe" + utf16Bytes(`{"name": "Zoë", "emoji": "😀"}`, false),
+			want:     `{"name":"Zoë","emoji":"😀"}`,
+			wantFlag: RepairEncoding,
+		},
+		{
+			in:       "\xfe\xff" + utf16Bytes(`{'a': [1, 2`, true),
+			want:     `{"a":[1,2]}`,
+			wantFlag: RepairEncoding,
+		},
+		{
+			in:       utf16Bytes(`{"a": "b"}`, false),
+			want:     `{"a":"b"}`,
+			wantFlag: RepairEncoding,
+		},
+		{
+			in:       utf16Bytes(`["x", "y"]`, true),
+			want:     `["x","y"]`,
+			wantFlag: RepairEncoding,
+		},
+		{
+			in:       "{\"city\": \"K\xf6ln\", \"price\": \"\x805\", \"ok\": \"é\"}",
+			opts:     []Option{WithInvalidUTF8(InvalidUTF8Windows1252)},
+			want:     `{"city":"Köln","price":"€5","ok":"é"}`,
+			wantFlag: RepairEncoding,
+		},
+		{
+			in:       "{\"a\": \"x\xffy\"}",
+			opts:     []Option{WithInvalidUTF8(InvalidUTF8Replace)},
+			want:     `{"a":"x�y"}`,
+			wantFlag: RepairInvalidUTF8,
+		},
+		{
+			in:       "\xff\xfe" + utf16Bytes(`["a", "b`, false) + "\x00",
+			want:     `["a","b\ufffd"]`,
+			wantFlag: RepairEncoding,
+		},
+		{
+			in:   "[\xb3]",
+			want: `[]`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, report, err := RepairJSONWithReport(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSONWithReport() = %v, want %v, param in is %q", got, tt.want, tt.in)
+			}
+
+			var flags []RepairFlag
+			for _, r := range report.Repairs {
+				flags = append(flags, r.Flag)
+			}
+			if tt.wantFlag != "" && !slices.Contains(flags, tt.wantFlag) {
+				t.Errorf("repairs = %v, want %v", flags, tt.wantFlag)
+			}
+		})
+	}
+
+	if _, err := RepairJSON("{\"a\": \"\xff\"}", WithInvalidUTF8(InvalidUTF8Error)); !errors.Is(err, ErrInvalidUTF8) {
+		t.Errorf("RepairJSON() error = %v, want %v", err, ErrInvalidUTF8)
+	}
+	if got := MustRepairJSON("{\"a\": \"\xff\"}", WithInvalidUTF8(InvalidUTF8Error)); got != "" {
+		t.Errorf("MustRepairJSON() = %v, want empty", got)
+	}
+}
@@ -0,0 +1,101 @@
+package jsonrepair
+
+import (
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// simpleEscapes are the single-character JSON escapes.
+var simpleEscapes = map[byte]byte{
+	'"':  '"',
+	'\\': '\\',
+	'/':  '/',
+	'b':  '\b',
+	'f':  '\f',
+	'n':  '\n',
+	'r':  '\r',
+	't':  '\t',
+}
+
+// parseEscape decodes the escape sequence starting with the backslash at
+// p.index, appends the result to rst and returns the repair it needed, if
+// any:
+//
+//   - \xNN becomes the code point U+00NN
+//   - a lone UTF-16 surrogate becomes U+FFFD
+//   - an unknown or truncated escape keeps its backslash when it escapes a
+//     letter or digit (regular expressions, Windows paths) and drops it
+//     before punctuation (Markdown escapes such as \_)
+func (p *JSONParser) parseEscape(rst []byte, delimiter byte) ([]byte, RepairFlag) {
+	c, b := p.getByte(1)
+	if !b {
+		// nothing left to escape
+		p.index++
+		return rst, ""
+	}
+	if ce, ok := simpleEscapes[c]; ok {
+		p.index += 2
+		return append(rst, ce), ""
+	}
+	if c == delimiter || c == '\'' {
+		p.index += 2
+		return append(rst, c), ""
+	}
+
+	switch c {
+	case 'u':
+		r, n := p.hexAt(2, 4)
+		if n < 4 {
+			break
+		}
+		p.index += 6
+		if !utf16.IsSurrogate(r) {
+			return utf8.AppendRune(rst, r), ""
+		}
+		if r < 0xdc00 {
+			if u, _ := p.getByte(0); u == '\\' {
+				if u, _ = p.getByte(1); u == 'u' {
+					if r2, n2 := p.hexAt(2, 4); n2 == 4 && r2 >= 0xdc00 && r2 <= 0xdfff {
+						p.index += 6
+						return utf8.AppendRune(rst, utf16.DecodeRune(r, r2)), ""
+					}
+				}
+			}
+		}
+		return utf8.AppendRune(rst, utf8.RuneError), RepairLoneSurrogate
+	case 'x':
+		if r, n := p.hexAt(2, 2); n == 2 {
+			p.index += 4
+			return utf8.AppendRune(rst, r), RepairHexEscape
+		}
+	}
+
+	p.index += 2
+	if isIdentByte(c) && c != '_' && c != '$' {
+		rst = append(rst, '\\')
+	}
+	return append(rst, c), RepairInvalidEscape
+}
+
+// hexAt reads up to n hexadecimal digits at p.index+offset and returns their
+// value and how many were read.
+func (p *JSONParser) hexAt(offset, n int) (rune, int) {
+	var r rune
+	i := 0
+	for ; i < n; i++ {
+		c, b := p.getByte(offset + i)
+		if !b || !isHexByte(c) {
+			break
+		}
+		switch {
+		case c >= 'a':
+			c -= 'a' - 10
+		case c >= 'A':
+			c -= 'A' - 10
+		default:
+			c -= '0'
+		}
+		r = r<<4 | rune(c)
+	}
+	return r, i
+}
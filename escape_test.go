@@ -0,0 +1,78 @@
+package jsonrepair
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_Escapes
+//
+//	Description:
+//	param t
+func Test_RepairJSON_Escapes(t *testing.T) {
+	tests := []struct {
+		in        string
+		want      string
+		wantFlags []RepairFlag
+	}{
+		{
+			in:        `{"a": "\x41\x42c", "b": 1`,
+			want:      `{"a":"ABc","b":1}`,
+			wantFlags: []RepairFlag{RepairHexEscape},
+		},
+		{
+			in:        `{"re": "\d+\.\d*", "path": "C:\Users\Bob"`,
+			want:      `{"re":"\\d+.\\d*","path":"C:\\Users\\Bob"}`,
+			wantFlags: []RepairFlag{RepairInvalidEscape},
+		},
+		{
+			in:        `{"a": "x\u12 y", "b": 1`,
+			want:      `{"a":"x\\u12 y","b":1}`,
+			wantFlags: []RepairFlag{RepairInvalidEscape},
+		},
+		{
+			in:        `{"pair": "\ud83d\ude00", "lone": "a\ud83db", "low": "\ude00"`,
+			want:      `{"pair":"😀","lone":"a�b","low":"�"}`,
+			wantFlags: []RepairFlag{RepairLoneSurrogate},
+		},
+		{
+			in:        "{\"a\": \"tab\there\x01\", \"b\": \"line\nbreak\"",
+			want:      `{"a":"tab\there\u0001","b":"line\nbreak"}`,
+			wantFlags: []RepairFlag{RepairControlCharacter},
+		},
+		{
+			in:   `{'a': '\'\"\/\b\f\n\r\t\\\u00e9'}`,
+			want: `{"a":"'\"/\b\f\n\r\t\\é"}`,
+		},
+		{
+			in:   `["\"quoted\" start", "end\`,
+			want: `["\"quoted\" start","end"]`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, report, err := RepairJSONWithReport(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSONWithReport() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+
+			var flags []RepairFlag
+			for _, r := range report.Repairs {
+				for _, f := range []RepairFlag{RepairHexEscape, RepairInvalidEscape, RepairLoneSurrogate, RepairControlCharacter} {
+					if r.Flag == f && !slices.Contains(flags, f) {
+						flags = append(flags, f)
+					}
+				}
+			}
+			if !reflect.DeepEqual(flags, tt.wantFlags) {
+				t.Errorf("escape repairs = %v, want %v", flags, tt.wantFlags)
+			}
+		})
+	}
+}
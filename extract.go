@@ -0,0 +1,168 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// defaultFenceLanguages are the info strings of code fences holding JSON.
+var defaultFenceLanguages = []string{"json", "jsonc", "json5"}
+
+// delimiter is one extraction rule of WithExtractTags, WithExtractMarkers
+// or WithExtractPattern.
+type delimiter struct {
+	tag        string
+	start, end string
+	pattern    *regexp.Regexp
+}
+
+// extractDocuments applies the extraction rules and the code fence
+// selection of o to src, returning the documents to repair and flags for
+// what was cut away. Input that is already valid JSON is returned as is.
+func extractDocuments(src string, o *options) ([]string, []RepairFlag) {
+	if len(o.extract) == 0 && !strings.Contains(src, "```") && !strings.Contains(src, "~~~") {
+		return []string{src}, nil
+	}
+	if json.Valid([]byte(src)) {
+		return []string{src}, nil
+	}
+
+	var flags []RepairFlag
+	if s, ok := extractDocument(src, o.extract); ok {
+		flags = append(flags, RepairExtracted)
+		src = s
+	}
+
+	// a document that starts as JSON keeps its fence-like lines, which are
+	// part of its (possibly multi-line) string values
+	if t := strings.TrimSpace(src); t != "" && (t[0] == '{' || t[0] == '[') {
+		return []string{src}, flags
+	}
+	fences := findCodeFences(src, o.fenceLanguages)
+	if len(fences) == 0 {
+		return []string{src}, flags
+	}
+	return selectFences(fences, o), append(flags, RepairCodeFence)
+}
+
+// extractDocument returns the text delimited by the first rule that
+// matches s. A start without its end, as in truncated output, extends to
+// the end of s.
+func extractDocument(s string, rules []delimiter) (string, bool) {
+	for _, d := range rules {
+		switch {
+		case d.pattern != nil:
+			loc := d.pattern.FindStringSubmatchIndex(s)
+			if loc == nil {
+				continue
+			}
+			if len(loc) >= 4 && loc[2] >= 0 {
+				return s[loc[2]:loc[3]], true
+			}
+			return s[loc[0]:loc[1]], true
+		case d.tag != "":
+			if inner, ok := extractTag(s, d.tag); ok {
+				return inner, true
+			}
+		default:
+			i := strings.Index(s, d.start)
+			if i < 0 {
+				continue
+			}
+			inner := s[i+len(d.start):]
+			if j := strings.Index(inner, d.end); j >= 0 {
+				inner = inner[:j]
+			}
+			return inner, true
+		}
+	}
+	return s, false
+}
+
+// extractTag returns the content of the first <name> element of s. Tag
+// names match case-insensitively and the opening tag may carry attributes.
+func extractTag(s, name string) (string, bool) {
+	open, closing := "<"+name, "</"+name+">"
+
+	for from := 0; ; {
+		i := indexFoldASCII(s, open, from)
+		if i < 0 {
+			return "", false
+		}
+		i += len(open)
+		from = i
+		if i >= len(s) || (s[i] != '>' && !isASCIISpace(rune(s[i])) && s[i] != '/') {
+			// a longer tag name, such as <jsonl> for <json>
+			continue
+		}
+		gt := strings.IndexByte(s[i:], '>')
+		if gt < 0 {
+			return "", false
+		}
+		if s[i+gt-1] == '/' {
+			// a self-closing tag has no content
+			continue
+		}
+		inner := s[i+gt+1:]
+		if j := indexFoldASCII(inner, closing, 0); j >= 0 {
+			inner = inner[:j]
+		}
+		return inner, true
+	}
+}
+
+// indexFoldASCII returns the index of the first instance of sub in s at or
+// after from, folding ASCII letters only so the offsets stay those of s, or
+// -1 if there is none.
+func indexFoldASCII(s, sub string, from int) int {
+	for i := from; i+len(sub) <= len(s); i++ {
+		if equalFoldASCII(s[i:i+len(sub)], sub) {
+			return i
+		}
+	}
+	return -1
+}
+
+// equalFoldASCII reports whether a and b, of the same length, are equal
+// ignoring the case of ASCII letters.
+func equalFoldASCII(a, b string) bool {
+	for i := 0; i < len(a); i++ {
+		x, y := a[i], b[i]
+		if 'A' <= x && x <= 'Z' {
+			x += 'a' - 'A'
+		}
+		if 'A' <= y && y <= 'Z' {
+			y += 'a' - 'A'
+		}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+// fenceLanguage returns the info string word following an opening fence,
+// such as json in ```json.
+func fenceLanguage(s string) string {
+	i := 0
+	for i < len(s) {
+		c := s[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+			c == '_' || c == '-' || c == '+' || c == '.') {
+			break
+		}
+		i++
+	}
+	return s[:i]
+}
+
+// isFenceLanguage reports whether lang is one of languages, ignoring case.
+func isFenceLanguage(lang string, languages []string) bool {
+	for _, l := range languages {
+		if strings.EqualFold(l, lang) {
+			return true
+		}
+	}
+	return false
+}
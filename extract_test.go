@@ -0,0 +1,163 @@
+package jsonrepair
+
+import (
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_Extract
+//
+//	Description:
+//	param t
+func Test_RepairJSON_Extract(t *testing.T) {
+	tests := []struct {
+		in        string
+		opts      []Option
+		want      string
+		extracted bool
+	}{
+		{
+			in:        `Let me [think] first. <json>{"a":1}</json>`,
+			opts:      []Option{WithExtractTags("json")},
+			want:      `{"a":1}`,
+			extracted: true,
+		},
+		{
+			in:        `<thinking>the user wants {"x": 1}</thinking><ANSWER type="final">{"a": [1, 2,]}</ANSWER>`,
+			opts:      []Option{WithExtractTags("json", "answer")},
+			want:      `{"a":[1,2]}`,
+			extracted: true,
+		},
+		{
+			in:        `<output>{"a":1}</output> Note: {"b": 2} was an example`,
+			opts:      []Option{WithExtractTags("output")},
+			want:      `{"a":1}`,
+			extracted: true,
+		},
+		{
+			in:        `<answer>"yes"</answer>`,
+			opts:      []Option{WithExtractTags("answer")},
+			want:      `"yes"`,
+			extracted: true,
+		},
+		{
+			in:        `<jsonl>[1]</jsonl><json>[2]</json>`,
+			opts:      []Option{WithExtractTags("json")},
+			want:      `[2]`,
+			extracted: true,
+		},
+		{
+			in:        "<json>\n```json\n{\"a\": 1}\n```\n</json>",
+			opts:      []Option{WithExtractTags("json")},
+			want:      `{"a":1}`,
+			extracted: true,
+		},
+		{
+			in:        "Result:\nBEGIN_JSON\n[1, 2, 3]\nEND_JSON\nDone [ok]",
+			opts:      []Option{WithExtractMarkers("BEGIN_JSON", "END_JSON")},
+			want:      `[1,2,3]`,
+			extracted: true,
+		},
+		{
+			in:        "BEGIN_JSON\n{\"items\": [1, 2",
+			opts:      []Option{WithExtractMarkers("BEGIN_JSON", "END_JSON")},
+			want:      `{"items":[1,2]}`,
+			extracted: true,
+		},
+		{
+			in:        `[draft] RESULT=>{"a": 1}<=RESULT`,
+			opts:      []Option{WithExtractPattern(regexp.MustCompile(`(?s)RESULT=>(.*)<=RESULT`))},
+			want:      `{"a":1}`,
+			extracted: true,
+		},
+		{
+			in:        `İİİİİİ Note {x}. <answer>{"a":1}</answer>`,
+			opts:      []Option{WithExtractTags("answer")},
+			want:      `{"a":1}`,
+			extracted: true,
+		},
+		{
+			in:        "\xff\xff\xff ȺȺȺȺ <Answer>{\"a\":1}</ANSWER> İ",
+			opts:      []Option{WithExtractTags("answer")},
+			want:      `{"a":1}`,
+			extracted: true,
+		},
+		{
+			in:   `{"html": "<json>[1]</json>"}`,
+			opts: []Option{WithExtractTags("json")},
+			want: `{"html":"<json>[1]</json>"}`,
+		},
+		{
+			in:   `Nothing tagged: {"a": 1,}`,
+			opts: []Option{WithExtractTags("json")},
+			want: `{"a":1}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, report, err := RepairJSONWithReport(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSONWithReport() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+			extracted := false
+			for _, r := range report.Repairs {
+				extracted = extracted || r.Flag == RepairExtracted
+			}
+			if extracted != tt.extracted {
+				t.Errorf("extracted = %v, want %v", extracted, tt.extracted)
+			}
+		})
+	}
+}
+
+// Test_RepairJSON_FenceLanguages
+//
+//	Description:
+//	param t
+func Test_RepairJSON_FenceLanguages(t *testing.T) {
+	tests := []struct {
+		in   string
+		opts []Option
+		want string
+	}{
+		{
+			in:   "```jsonc\n42\n```",
+			want: `42`,
+		},
+		{
+			in:   "```JSON5\n\"hello\"\n```",
+			want: `"hello"`,
+		},
+		{
+			in:   "```json[1]```",
+			want: `[1]`,
+		},
+		{
+			in:   "```javascript\ntrue\n```",
+			opts: []Option{WithFenceLanguages(append(DefaultFenceLanguages(), "javascript")...)},
+			want: `true`,
+		},
+		{
+			in:   "```\n{\"a\": 1}\n```",
+			opts: []Option{WithFenceLanguages()},
+			want: `{"a":1}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+}
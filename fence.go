@@ -0,0 +1,173 @@
+package jsonrepair
+
+import "strings"
+
+// FenceSelection
+//
+//	Description: which code fenced block holds the document when a response
+//	has several, see WithFenceSelection
+type FenceSelection int
+
+const (
+	// FenceLast takes the last block, typically the answer after examples
+	// (default).
+	FenceLast FenceSelection = iota
+	// FenceFirst takes the first block.
+	FenceFirst
+	// FenceLargest takes the longest block.
+	FenceLargest
+	// FenceSchema takes the first block whose document fits the schema of
+	// WithFenceSchema, or the last block if none does.
+	FenceSchema
+	// FenceAll repairs every block and returns them as an array, or the
+	// document of the only block.
+	FenceAll
+)
+
+// findCodeFences returns the content of the fenced blocks of s whose language is one of
+// languages or that have no info string. Fences open with a line of three
+// or more '`' or '~', possibly indented, and close with a line of at least
+// as many of the same character; an unclosed fence extends to the end of s.
+// The indentation of the opening fence is removed from every line.
+func findCodeFences(s string, languages []string) []string {
+	var fences []string
+	lines := strings.SplitAfter(s, "\n")
+	for i := 0; i < len(lines); i++ {
+		indent, marker, info, ok := openingFence(lines[i])
+		if !ok {
+			continue
+		}
+
+		var sb strings.Builder
+		j := i + 1
+		for ; j < len(lines); j++ {
+			if isClosingFence(lines[j], marker) {
+				break
+			}
+			sb.WriteString(trimIndent(lines[j], indent))
+		}
+		i = j
+
+		if lang := fenceLanguage(info); lang != "" && !isFenceLanguage(lang, languages) {
+			continue
+		}
+		if content := strings.TrimSpace(sb.String()); content != "" {
+			fences = append(fences, content)
+		}
+	}
+	return fences
+}
+
+// openingFence parses an opening fence line into its indentation width,
+// fence marker and info string. The info string of a backtick fence may not
+// contain a backtick, and its first word must end at a space, so
+// ```json[1]``` and ```json{"a": ... with the document on the fence line
+// are not fences.
+func openingFence(line string) (indent int, marker, info string, ok bool) {
+	rest := strings.TrimLeft(line, " \t")
+	indent = len(line) - len(rest)
+	if len(rest) < 3 || (rest[0] != '`' && rest[0] != '~') {
+		return 0, "", "", false
+	}
+	n := 0
+	for n < len(rest) && rest[n] == rest[0] {
+		n++
+	}
+	if n < 3 {
+		return 0, "", "", false
+	}
+	info = strings.TrimSpace(rest[n:])
+	if rest[0] == '`' && strings.Contains(info, "`") {
+		return 0, "", "", false
+	}
+	if lang := fenceLanguage(info); len(info) > len(lang) && !isASCIISpace(rune(info[len(lang)])) {
+		return 0, "", "", false
+	}
+	return indent, rest[:n], info, true
+}
+
+// isClosingFence reports whether line closes a fence opened with marker.
+func isClosingFence(line, marker string) bool {
+	rest := strings.TrimSpace(line)
+	return len(rest) >= len(marker) && strings.Trim(rest, marker[:1]) == ""
+}
+
+// trimIndent removes up to width leading spaces or tabs from line.
+func trimIndent(line string, width int) string {
+	i := 0
+	for i < width && i < len(line) && (line[i] == ' ' || line[i] == '\t') {
+		i++
+	}
+	return line[i:]
+}
+
+// selectFences picks the blocks holding the document under the fence
+// selection of o.
+func selectFences(fences []string, o *options) []string {
+	switch o.fenceSelection {
+	case FenceFirst:
+		return fences[:1]
+	case FenceLargest:
+		largest := 0
+		for i, f := range fences {
+			if len(f) > len(fences[largest]) {
+				largest = i
+			}
+		}
+		return fences[largest : largest+1]
+	case FenceSchema:
+		for i, f := range fences {
+			if fitsSchema(f, o.fenceSchema, o) {
+				return fences[i : i+1]
+			}
+		}
+		return fences[len(fences)-1:]
+	case FenceAll:
+		return fences
+	}
+	return fences[len(fences)-1:]
+}
+
+// fitsSchema reports whether the repaired document src fits schema as it
+// is, without coercion. A nil schema fits anything.
+func fitsSchema(src string, schema *toolSchema, o *options) bool {
+	if schema == nil {
+		return true
+	}
+	_, root, _, err := repairDocument(src, true, blockOptions(o))
+	if err != nil {
+		return false
+	}
+	c := &schemaCoercer{validate: true}
+	c.coerce("", schema, root)
+	return len(c.problems) == 0
+}
+
+// blockOptions returns the options for repairing the content of a block,
+// which must not be extracted from again.
+func blockOptions(o *options) *options {
+	inner := *o
+	inner.extract = nil
+	return &inner
+}
+
+// repairDocuments repairs every document of docs separately and returns
+// them as an array. Offsets inside each element are relative to its
+// document.
+func repairDocuments(docs []string, flags []RepairFlag, o *options) (string, *Node, error) {
+	root := &Node{Kind: ArrayNode}
+	parts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		dst, n, _, err := repairDocument(doc, true, blockOptions(o))
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, dst)
+		root.Children = append(root.Children, n)
+	}
+	for _, f := range flags {
+		root.addRepair(f)
+	}
+	root.addRepair(RepairMultipleRoots)
+	return "[" + strings.Join(parts, ",") + "]", root, nil
+}
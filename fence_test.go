@@ -0,0 +1,127 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+)
+
+const twoFences = "Here is an example:\n```json\n{\"name\": \"example\"}\n```\n" +
+	"Answer [final]:\n```json\n{\"name\": \"real\", \"items\": [1, 2,]}\n```\nDone."
+
+// Test_RepairJSON_FenceSelection
+//
+//	Description:
+//	param t
+func Test_RepairJSON_FenceSelection(t *testing.T) {
+	tests := []struct {
+		in   string
+		opts []Option
+		want string
+	}{
+		{
+			in:   twoFences,
+			want: `{"name":"real","items":[1,2]}`,
+		},
+		{
+			in:   twoFences,
+			opts: []Option{WithFenceSelection(FenceAll)},
+			want: `[{"name":"example"},{"name":"real","items":[1,2]}]`,
+		},
+		{
+			in:   twoFences,
+			opts: []Option{WithFenceSelection(FenceFirst)},
+			want: `{"name":"example"}`,
+		},
+		{
+			in:   twoFences,
+			opts: []Option{WithFenceSelection(FenceLast)},
+			want: `{"name":"real","items":[1,2]}`,
+		},
+		{
+			in:   twoFences,
+			opts: []Option{WithFenceSelection(FenceLargest)},
+			want: `{"name":"real","items":[1,2]}`,
+		},
+		{
+			in:   twoFences,
+			opts: []Option{WithFenceSchema(json.RawMessage(`{"type": "object", "required": ["items"]}`))},
+			want: `{"name":"real","items":[1,2]}`,
+		},
+		{
+			in: "```json\n{\"id\": 1}\n```\n```json\n{\"id\": \"two\"}\n```",
+			opts: []Option{WithFenceSchema(json.RawMessage(
+				`{"type": "object", "properties": {"id": {"type": "string"}}}`))},
+			want: `{"id":"two"}`,
+		},
+		{
+			in:   "```json\n{\"a\": [1, 2\n```\n```json\n{\"b\": 3}\n```",
+			opts: []Option{WithFenceSelection(FenceAll)},
+			want: `[{"a":[1,2]},{"b":3}]`,
+		},
+		{
+			in:   "```python\nprint('x')\n```\n```json\n[1]\n```",
+			want: `[1]`,
+		},
+		{
+			in:   "1. The result:\n\n    ```json\n    {\n      \"a\": 1\n    }\n    ```\n",
+			want: `{"a":1}`,
+		},
+		{
+			in:   "~~~json\n[\"~~~\"]\n~~~",
+			want: `["~~~"]`,
+		},
+		{
+			in:   "```json title=\"result.json\" {.wide}\n{\"a\": 1}\n```",
+			want: `{"a":1}`,
+		},
+		{
+			in:   "```json\n{\"a\": [1, 2",
+			want: `{"a":[1,2]}`,
+		},
+		{
+			in:   "{\"answer\": \"Here is code:\n```\nx = 1\n```\n\", \"b\": 2",
+			want: `{"answer":"Here is code:\n` + "```" + `\nx = 1"}`,
+		},
+		{
+			in:   "```json{\"a\": 1}```",
+			want: `{"a":1}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+}
+
+// Test_RepairJSONWithReport_FenceAll
+//
+//	Description:
+//	param t
+func Test_RepairJSONWithReport_FenceAll(t *testing.T) {
+	_, report, err := RepairJSONWithReport(twoFences, WithFenceSelection(FenceAll))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[RepairFlag]string{
+		RepairCodeFence:     "",
+		RepairMultipleRoots: "",
+		RepairTrailingComma: "/1/items",
+	}
+	if len(report.Repairs) != len(want) {
+		t.Fatalf("Repairs = %v, want %v", report.Repairs, want)
+	}
+	for _, r := range report.Repairs {
+		if path, ok := want[r.Flag]; !ok || path != r.Path {
+			t.Errorf("Repairs = %v, want %v", report.Repairs, want)
+		}
+	}
+}
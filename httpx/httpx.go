@@ -0,0 +1,184 @@
+// Package httpx provides net/http middleware that repairs broken JSON
+// request bodies, such as those posted by LLM agents, before they reach the
+// wrapped handler.
+package httpx
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/RealAlexandreAI/json-repair"
+)
+
+const (
+	// HeaderRepaired is "true" when the request body was repaired and
+	// "false" when it was already valid JSON.
+	HeaderRepaired = "X-Json-Repaired"
+	// HeaderConfidence is the confidence of the repair, from 0 to 1.
+	HeaderConfidence = "X-Json-Repair-Confidence"
+	// HeaderRepairs lists the distinct flags of the applied repairs, comma
+	// separated, in the order they first occur in the document.
+	HeaderRepairs = "X-Json-Repairs"
+)
+
+// DefaultMaxBodySize is the body size limit used unless WithMaxBodySize is
+// given.
+const DefaultMaxBodySize int64 = 1 << 20
+
+type options struct {
+	maxBodySize   int64
+	minConfidence float64
+	repairOptions []jsonrepair.Option
+}
+
+// Option
+//
+//	Description: configures Middleware
+type Option func(*options)
+
+// WithMaxBodySize
+//
+//	Description: rejects bodies larger than n bytes with 413 Request Entity
+//	Too Large. A limit of zero or less disables the check.
+//	param n
+//	return Option
+func WithMaxBodySize(n int64) Option {
+	return func(o *options) {
+		o.maxBodySize = n
+	}
+}
+
+// WithMinConfidence
+//
+//	Description: rejects requests whose repair confidence is below c with
+//	422 Unprocessable Entity. Valid JSON always has confidence 1.
+//	param c
+//	return Option
+func WithMinConfidence(c float64) Option {
+	return func(o *options) {
+		o.minConfidence = c
+	}
+}
+
+// WithRepairOptions
+//
+//	Description: passes opts to the repairer
+//	param opts
+//	return Option
+func WithRepairOptions(opts ...jsonrepair.Option) Option {
+	return func(o *options) {
+		o.repairOptions = append(o.repairOptions, opts...)
+	}
+}
+
+// Middleware
+//
+//	Description: wraps next so that application/json request bodies (and
+//	other +json media types) are repaired before next reads them. The
+//	repair outcome is described by the HeaderRepaired, HeaderConfidence and
+//	HeaderRepairs headers, which are set both on the request seen by next
+//	and on the response. These headers are removed from every incoming
+//	request, so next never sees values sent by the client. Other requests
+//	and empty bodies pass through.
+//	param next
+//	param opts
+//	return http.Handler
+func Middleware(next http.Handler, opts ...Option) http.Handler {
+	o := &options{maxBodySize: DefaultMaxBodySize}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// only the middleware may describe the repair
+		for _, h := range []string{HeaderRepaired, HeaderConfidence, HeaderRepairs} {
+			r.Header.Del(h)
+		}
+
+		if r.Body == nil || r.Body == http.NoBody || !isJSON(r.Header.Get("Content-Type")) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		body, err := readBody(w, r, o.maxBodySize)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, "cannot read request body", http.StatusBadRequest)
+			return
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		dst, report, err := jsonrepair.RepairJSONWithReport(string(body), o.repairOptions...)
+		if err != nil {
+			http.Error(w, "cannot repair request body: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var flags []string
+		seen := map[jsonrepair.RepairFlag]bool{}
+		for _, entry := range report.Repairs {
+			if !seen[entry.Flag] {
+				seen[entry.Flag] = true
+				flags = append(flags, string(entry.Flag))
+			}
+		}
+		headers := map[string]string{
+			HeaderRepaired:   strconv.FormatBool(report.Repaired()),
+			HeaderConfidence: strconv.FormatFloat(report.Confidence, 'f', -1, 64),
+		}
+		if len(flags) > 0 {
+			headers[HeaderRepairs] = strings.Join(flags, ",")
+		}
+		for k, v := range headers {
+			r.Header.Set(k, v)
+			w.Header().Set(k, v)
+		}
+
+		if report.Confidence < o.minConfidence {
+			http.Error(w, "request body repair confidence too low", http.StatusUnprocessableEntity)
+			return
+		}
+
+		if report.Repaired() {
+			body = []byte(dst)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		r.Header.Set("Content-Length", strconv.Itoa(len(body)))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readBody reads the request body, failing with *http.MaxBytesError when it
+// is longer than limit.
+func readBody(w http.ResponseWriter, r *http.Request, limit int64) ([]byte, error) {
+	body := r.Body
+	if limit > 0 {
+		body = http.MaxBytesReader(w, body, limit)
+	}
+	defer body.Close()
+	return io.ReadAll(body)
+}
+
+// isJSON reports whether contentType is application/json or a +json media
+// type.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
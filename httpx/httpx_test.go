@@ -0,0 +1,145 @@
+package httpx
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test_Middleware
+//
+//	Description:
+//	param t
+func Test_Middleware(t *testing.T) {
+	tests := []struct {
+		contentType string
+		body        string
+		opts        []Option
+		wantStatus  int
+		wantBody    string
+		repaired    string
+		confidence  string
+		repairs     string
+	}{
+		{
+			contentType: "application/json",
+			body:        `{"a": 1}`,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"a": 1}`,
+			repaired:    "false",
+			confidence:  "1",
+		},
+		{
+			contentType: "application/json; charset=utf-8",
+			body:        `{"a": 1, "b": [1, 2,]`,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"a":1,"b":[1,2]}`,
+			repaired:    "true",
+		},
+		{
+			contentType: "application/vnd.api+json",
+			body:        `{'a': 1}`,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"a":1}`,
+			repaired:    "true",
+			confidence:  "0.98",
+		},
+		{
+			contentType: "text/plain",
+			body:        `{'a': 1}`,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{'a': 1}`,
+		},
+		{
+			contentType: "application/json",
+			body:        "",
+			wantStatus:  http.StatusOK,
+			wantBody:    "",
+		},
+		{
+			contentType: "application/json",
+			body:        `{"a": "` + strings.Repeat("x", 64) + `"}`,
+			opts:        []Option{WithMaxBodySize(32)},
+			wantStatus:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			contentType: "application/json",
+			body:        `{"name": "John is "good",hah"}`,
+			opts:        []Option{WithMinConfidence(0.9)},
+			wantStatus:  http.StatusUnprocessableEntity,
+			repaired:    "true",
+			confidence:  "0.5",
+		},
+		{
+			contentType: "application/json",
+			body:        `{'a': 1}`,
+			opts:        []Option{WithMinConfidence(0.9)},
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"a":1}`,
+			repaired:    "true",
+		},
+		{
+			contentType: "application/json",
+			body:        `{'a': 'x', 'b': ['y', 'z',]}`,
+			wantStatus:  http.StatusOK,
+			wantBody:    `{"a":"x","b":["y","z"]}`,
+			repaired:    "true",
+			repairs:     "single_quotes,trailing_comma",
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			var gotBody, gotRepaired, gotConfidence, gotRepairs string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				bs, _ := io.ReadAll(r.Body)
+				gotBody = string(bs)
+				gotRepaired = r.Header.Get(HeaderRepaired)
+				gotConfidence = r.Header.Get(HeaderConfidence)
+				gotRepairs = r.Header.Get(HeaderRepairs)
+				if r.ContentLength != int64(len(bs)) {
+					t.Errorf("ContentLength = %d, want %d", r.ContentLength, len(bs))
+				}
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			// headers sent by the client must not reach next
+			req.Header.Set(HeaderRepaired, "spoofed")
+			req.Header.Set(HeaderConfidence, "spoofed")
+			req.Header.Set(HeaderRepairs, "spoofed")
+			rec := httptest.NewRecorder()
+			Middleware(next, tt.opts...).ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK {
+				if gotBody != tt.wantBody {
+					t.Errorf("body = %s, want %s", gotBody, tt.wantBody)
+				}
+				if gotRepaired != tt.repaired {
+					t.Errorf("request %s = %q, want %q", HeaderRepaired, gotRepaired, tt.repaired)
+				}
+				if gotConfidence != rec.Header().Get(HeaderConfidence) || gotRepairs != rec.Header().Get(HeaderRepairs) {
+					t.Errorf("request %s, %s = %q, %q", HeaderConfidence, HeaderRepairs, gotConfidence, gotRepairs)
+				}
+			}
+			if got := rec.Header().Get(HeaderRepaired); got != tt.repaired {
+				t.Errorf("response %s = %q, want %q", HeaderRepaired, got, tt.repaired)
+			}
+			if got := rec.Header().Get(HeaderConfidence); tt.confidence != "" && got != tt.confidence {
+				t.Errorf("response %s = %q, want %q", HeaderConfidence, got, tt.confidence)
+			}
+			if tt.repaired == "true" && rec.Header().Get(HeaderRepairs) == "" {
+				t.Errorf("response %s is empty", HeaderRepairs)
+			}
+			if got := rec.Header().Get(HeaderRepairs); tt.repairs != "" && got != tt.repairs {
+				t.Errorf("response %s = %q, want %q", HeaderRepairs, got, tt.repairs)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"strings"
+)
+
+// defaultJavaScriptValues maps the JavaScript values without a JSON
+// equivalent the way JSON.stringify does.
+var defaultJavaScriptValues = map[string]any{
+	"undefined": nil,
+	"NaN":       nil,
+	"Infinity":  nil,
+	"-Infinity": nil,
+}
+
+// translateJavaScript rewrites JavaScript object literal and JSON5 syntax
+// into JSON-like text for the parser:
+//
+//   - undefined, NaN, Infinity, -Infinity → values from the mapping
+//   - hexadecimal, octal and binary integers, numeric separators (1_000),
+//     leading '+' and bare decimal points (.5, 5.) → JSON numbers
+//   - single-quoted and backtick template strings → double-quoted JSON strings
+//   - identifier keys, including '$' and '_' → quoted keys
+//
+// Comments are copied verbatim for stripComments to remove.
+func translateJavaScript(s string, values map[string]any) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	value := func(name string) string {
+		v, ok := values[name]
+		if !ok {
+			v = defaultJavaScriptValues[name]
+		}
+		bs, _ := JSONMarshal(v)
+		return strings.TrimSuffix(string(bs), "\n")
+	}
+
+	i := 0
+	for i < len(s) {
+		c := s[i]
+
+		switch {
+		case strings.HasPrefix(s[i:], "```"):
+			// code fence inside a value, see parseJSONLLMBlock
+			sb.WriteString("```")
+			i += 3
+		case strings.HasPrefix(s[i:], "//"):
+			end := strings.IndexAny(s[i:], "\r\n")
+			if end < 0 {
+				end = len(s) - i
+			}
+			sb.WriteString(s[i : i+end])
+			i += end
+		case strings.HasPrefix(s[i:], "/*"):
+			end := strings.Index(s[i+2:], "*/")
+			if end < 0 {
+				end = len(s) - i
+			} else {
+				end += 4
+			}
+			sb.WriteString(s[i : i+end])
+			i += end
+		case c == '"' || c == '\'' || c == '`':
+			i = translateStringLiteral(&sb, s, i, s[i:i+1], false, c == '`')
+		case isIdentStart(c) && (i == 0 || !isIdentByte(s[i-1])):
+			j := i
+			for j < len(s) && isIdentByte(s[j]) {
+				j++
+			}
+			word := s[i:j]
+			k := j
+			for k < len(s) && (s[k] == ' ' || s[k] == '\t') {
+				k++
+			}
+			switch {
+			case k < len(s) && s[k] == ':':
+				sb.WriteString(quoteJSONString(word))
+			case word == "undefined" || word == "NaN" || word == "Infinity":
+				sb.WriteString(value(word))
+			default:
+				sb.WriteString(word)
+			}
+			i = j
+		case (i == 0 || !isIdentByte(s[i-1])) && jsNumberLen(s[i:]) > 0:
+			n := jsNumberLen(s[i:])
+			sb.WriteString(translateJavaScriptNumber(s[i:i+n], value))
+			i += n
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+
+	return sb.String()
+}
+
+// jsNumberLen returns the length of the JavaScript number literal at the
+// start of s, including a sign and a signed Infinity or NaN, or 0.
+func jsNumberLen(s string) int {
+	i := 0
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+	for _, word := range []string{"Infinity", "NaN"} {
+		if strings.HasPrefix(s[i:], word) && (i+len(word) == len(s) || !isIdentByte(s[i+len(word)])) {
+			return i + len(word)
+		}
+	}
+
+	digits := i
+	if len(s) > i+1 && s[i] == '0' && strings.IndexByte("xXoObB", s[i+1]) != -1 {
+		i += 2
+		for i < len(s) && (isHexByte(s[i]) || s[i] == '_') {
+			i++
+		}
+		return i
+	}
+	for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '_' || s[i] == '.') {
+		i++
+	}
+	if i == digits || strings.Trim(s[digits:i], "._") == "" {
+		return 0
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if j < len(s) && (s[j] == '+' || s[j] == '-') {
+			j++
+		}
+		if j < len(s) && s[j] >= '0' && s[j] <= '9' {
+			for j < len(s) && (s[j] >= '0' && s[j] <= '9' || s[j] == '_') {
+				j++
+			}
+			i = j
+		}
+	}
+	return i
+}
+
+// translateJavaScriptNumber returns the JSON form of the number literal lit.
+func translateJavaScriptNumber(lit string, value func(string) string) string {
+	sign := ""
+	if lit[0] == '+' || lit[0] == '-' {
+		if lit[0] == '-' {
+			sign = "-"
+		}
+		lit = lit[1:]
+	}
+
+	switch {
+	case lit == "Infinity":
+		return value(sign + "Infinity")
+	case lit == "NaN":
+		return value("NaN")
+	}
+
+	lit = strings.ReplaceAll(lit, "_", "")
+	if len(lit) > 1 && lit[0] == '0' && strings.IndexByte("xXoObB", lit[1]) != -1 {
+		if n, err := strconv.ParseUint(lit, 0, 64); err == nil {
+			return sign + strconv.FormatUint(n, 10)
+		}
+		return sign + lit
+	}
+
+	if strings.HasPrefix(lit, ".") {
+		lit = "0" + lit
+	}
+	if i := strings.IndexByte(lit, '.'); i >= 0 && (i == len(lit)-1 || lit[i+1] == 'e' || lit[i+1] == 'E') {
+		lit = lit[:i+1] + "0" + lit[i+1:]
+	}
+	return sign + lit
+}
+
+func isHexByte(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
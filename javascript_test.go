@@ -0,0 +1,72 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_JavaScript
+//
+//	Description:
+//	param t
+func Test_RepairJSON_JavaScript(t *testing.T) {
+	tests := []struct {
+		in     string
+		values map[string]any
+		want   string
+	}{
+		{
+			in:   `{$id: 1, _private: 'x', camelCase: "y", 'single': 'it\'s'}`,
+			want: `{"$id":1,"_private":"x","camelCase":"y","single":"it's"}`,
+		},
+		{
+			in:   `{a: undefined, b: NaN, c: Infinity, d: -Infinity,}`,
+			want: `{"a":null,"b":null,"c":null,"d":null}`,
+		},
+		{
+			in:     `{a: undefined, b: NaN, c: Infinity, d: -Infinity}`,
+			values: map[string]any{"NaN": "NaN", "Infinity": "Infinity", "-Infinity": "-Infinity", "undefined": "undefined"},
+			want:   `{"a":"undefined","b":"NaN","c":"Infinity","d":"-Infinity"}`,
+		},
+		{
+			in:   `{hex: 0x1F, oct: 0o17, bin: 0b101, big: 1_000_000, plus: +5, half: .5, whole: 5., exp: 1_0e1_0}`,
+			want: `{"hex":31,"oct":15,"bin":5,"big":1000000,"plus":5,"half":0.5,"whole":5.0,"exp":10e10}`,
+		},
+		{
+			in:   "{tpl: `line 1\nline \"2\" ${name}`, esc: '\\x41\\u{1F600}'}",
+			want: `{"tpl":"line 1\nline \"2\" ${name}","esc":"A😀"}`,
+		},
+		{
+			in:   "// config\n{\n  name: 'app', // don't ship\n  /* it's fine */ port: 8080,\n}",
+			want: `{"name":"app","port":8080}`,
+		},
+		{
+			in:   `[1, -2, +3, NaN, 'NaN', "Infinity"]`,
+			want: `[1,-2,3,null,"NaN","Infinity"]`,
+		},
+		{
+			in:   `{key_1: value, "x-y": 2`,
+			want: `{"key_1":"value","x-y":2}`,
+		},
+		{
+			in:   `{a: "\ud83d\ude00", b: 'a\/b', c: '\u{1F600}', d: "\udc00"}`,
+			want: `{"a":"😀","b":"a/b","c":"😀","d":"\ufffd"}`,
+		},
+		{
+			in:   `{"a": "\ud83d\ude00", "b": "a\/b"}`,
+			want: `{"a":"😀","b":"a/b"}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, WithJavaScriptLiterals(tt.values))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+}
@@ -16,9 +16,10 @@ import (
 //
 //	@Description:
 //	@param src
+//	@param opts
 //	@return dst
 //	@return err
-func RepairJSON(src string) (dst string, err error) {
+func RepairJSON(src string, opts ...Option) (dst string, err error) {
 	defer func() {
 		if errR := recover(); errR != nil {
 			stack := string(debug.Stack())
@@ -27,39 +28,111 @@ func RepairJSON(src string) (dst string, err error) {
 		}
 	}()
 
-	src = normalizeInput(src)
+	dst, _, _, err = repairDocument(src, false, newOptions(opts))
+	return
+}
 
-	if json.Valid([]byte(src)) {
+// repairDocument runs the RepairJSON pipeline on src. The tree of the
+// document is only built for input that is already valid JSON if withTree
+// is set, or to find elision comments under ElisionError; it is always
+// built when the parser had to repair the input. layers counts the layers
+// of string encoding around the document after decoding and extraction,
+// whether or not WithUnwrapEncoded removed them.
+func repairDocument(src string, withTree bool, o *options) (dst string, root *Node, layers int, err error) {
+	src, flags, err := decodeInput(src, o)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	unwrapped := false
+	if s, n := unwrapEncoded(src); n > 0 {
+		layers = n
+		if o.unwrapEncoded {
+			flags = append(flags, RepairUnwrappedEncoding)
+			src, unwrapped = s, true
+		}
+	}
+	docs, extracted := extractDocuments(src, o)
+	flags = append(flags, extracted...)
+	if len(docs) > 1 {
+		dst, root, err = repairDocuments(docs, flags, o)
+		return dst, root, layers, err
+	}
+	if len(extracted) > 0 {
+		// a fenced or tagged document may be encoded inside its block
+		if s, n := unwrapEncoded(docs[0]); n > 0 {
+			layers += n
+			if o.unwrapEncoded {
+				if !unwrapped {
+					flags = append(flags, RepairUnwrappedEncoding)
+				}
+				docs[0] = s
+			}
+		}
+	}
+	src, normalized, comments := normalizeInput(docs[0], o)
+	flags = append(flags, normalized...)
+
+	valid := json.Valid([]byte(src))
+	if valid {
 		buf := &bytes.Buffer{}
 		if err = json.Compact(buf, []byte(src)); err != nil {
-			return "", err
+			return "", nil, 0, err
 		}
 		dst = buf.String()
-		return
-	}
+		if withTree || o.nested != NestedJSONOff || (len(comments) > 0 && o.elision == ElisionError) {
+			if root, err = buildValidAST(src); err != nil {
+				return "", nil, 0, err
+			}
+		}
+	} else {
+		jp := newJSONParser(src, o)
+		root = jp.parseDocument()
+
+		// Try to marshal the result
+		bs, err := JSONMarshal(root.Interface())
+		if err != nil {
+			return "", nil, 0, err
+		}
 
-	jp := NewJSONParser(src)
-	result := jp.parseJSON()
-	result = jp.collectMultipleTopLevel(result)
+		// If the result is valid JSON, trim it and only keep the valid part
+		dst = strings.TrimSpace(string(bs))
+	}
 
-	// Try to marshal the result
-	bs, err := JSONMarshal(result)
-	if err != nil {
-		return "", err
+	if root != nil {
+		for _, f := range flags {
+			root.addRepair(f)
+		}
+		for _, c := range comments {
+			root.attachComment(c)
+		}
+		if markElidedComments(root) && o.elision == ElisionError {
+			return "", nil, 0, ErrElision
+		}
 	}
-	dst = string(bs)
 
-	// If the result is valid JSON, trim it and only keep the valid part
-	dst = strings.TrimSpace(dst)
-	return
+	if root != nil && o.nested != NestedJSONOff && expandNestedJSON(root, o) {
+		var bs []byte
+		if valid {
+			// keep the member order of the input, as json.Compact does
+			bs, err = MarshalNode(root, FormatJSON)
+		} else {
+			bs, err = JSONMarshal(root.Interface())
+		}
+		if err != nil {
+			return "", nil, 0, err
+		}
+		dst = strings.TrimSpace(string(bs))
+	}
+	return dst, root, layers, nil
 }
 
 // MustRepairJSON
 //
 //	@Description:
 //	@param src
+//	@param opts
 //	@return dst
-func MustRepairJSON(src string) (dst string) {
+func MustRepairJSON(src string, opts ...Option) (dst string) {
 	defer func() {
 		if errR := recover(); errR != nil {
 			dst = ""
@@ -67,31 +140,20 @@ func MustRepairJSON(src string) (dst string) {
 		}
 	}()
 
-	src = normalizeInput(src)
-
-	if json.Valid([]byte(src)) {
-		buf := &bytes.Buffer{}
-		//nolint
-		json.Compact(buf, []byte(src))
-		dst = buf.String()
-		return
+	dst, _, _, err := repairDocument(src, false, newOptions(opts))
+	if err != nil {
+		return ""
 	}
-
-	jp := NewJSONParser(src)
-	result := jp.parseJSON()
-	result = jp.collectMultipleTopLevel(result)
-	bs, _ := JSONMarshal(result)
-	dst = string(bs)
 	return
 }
 
 // collectMultipleTopLevel handles multiple sequential JSON values (upstream _parse_top_level).
 // If there are remaining elements after the first, they are collected into an array.
-func (p *JSONParser) collectMultipleTopLevel(result any) any {
+func (p *JSONParser) collectMultipleTopLevel(result *Node) *Node {
 	if p.index >= len(p.container) {
 		return result
 	}
-	elements := []any{result}
+	elements := []*Node{result}
 	for p.index < len(p.container) {
 		p.skipWhitespaces()
 		c, b := p.getByte(0)
@@ -105,7 +167,7 @@ func (p *JSONParser) collectMultipleTopLevel(result any) any {
 		}
 		if c == '{' || c == '[' || c == '"' || c == '\'' || (c >= '0' && c <= '9') || c == '-' || c == '.' {
 			elem := p.parseJSON()
-			if elem != nil && elem != "" {
+			if !elem.isEmpty() {
 				elements = append(elements, elem)
 			}
 		} else {
@@ -113,7 +175,9 @@ func (p *JSONParser) collectMultipleTopLevel(result any) any {
 		}
 	}
 	if len(elements) > 1 {
-		return elements
+		root := &Node{Kind: ArrayNode, Children: elements, Start: result.Start, End: p.pos()}
+		root.addRepair(RepairMultipleRoots)
+		return root
 	}
 	return result
 }
@@ -122,12 +186,18 @@ func (p *JSONParser) collectMultipleTopLevel(result any) any {
 //
 //	Description:
 //	param in
+//	param opts
 //	return *JSONParser
-func NewJSONParser(in string) *JSONParser {
+func NewJSONParser(in string, opts ...Option) *JSONParser {
+	return newJSONParser(in, newOptions(opts))
+}
+
+func newJSONParser(in string, o *options) *JSONParser {
 	return &JSONParser{
 		container: in,
 		index:     0,
 		marker:    []string{},
+		opts:      o,
 	}
 }
 
@@ -139,6 +209,7 @@ type JSONParser struct {
 	marker           []string
 	recursionDepth   int
 	rstringDelimiter byte
+	opts             *options
 }
 
 const maxRecursionDepth = 1000
@@ -147,31 +218,32 @@ const maxRecursionDepth = 1000
 //
 //	Description:
 //	receiver p
-//	return any
-func (p *JSONParser) parseJSON() any {
+//	return *Node
+func (p *JSONParser) parseJSON() *Node {
 	// Prevent stack overflow by checking recursion depth
 	p.recursionDepth++
 	defer func() { p.recursionDepth-- }()
 
 	if p.recursionDepth > maxRecursionDepth {
-		return ""
+		return p.emptyNode()
 	}
 
 	startIndex := p.index
 	consecutiveNoProgress := 0
+	skipped := false
 
 	for {
 		c, b := p.getByte(0)
 
 		if !b {
-			return ""
+			return p.emptyNode()
 		}
 
 		// Detect infinite loop: if we haven't moved forward in several iterations
 		if p.index == startIndex {
 			consecutiveNoProgress++
 			if consecutiveNoProgress > 10 {
-				return ""
+				return p.emptyNode()
 			}
 		} else {
 			startIndex = p.index
@@ -180,31 +252,48 @@ func (p *JSONParser) parseJSON() any {
 
 		isInMarkers := len(p.marker) > 0
 
+		var n *Node
+
 		// Smart quote dispatch — must check rune before ASCII-byte switch since getByte returns only first byte
 		if isInMarkers {
 			if asciiQuote, ok := getSmartQuoteByteAt(p.container, p.index, 0); ok {
 				_, sz := utf8.DecodeRuneInString(p.container[p.index:])
 				p.index += sz
 				p.rstringDelimiter = asciiQuote
-				return p.parseString()
+				n = p.parseString()
 			}
 		}
 
 		switch {
+		case n != nil:
+		case isInMarkers && p.elisionMarkerLen() > 0:
+			n = p.parseElision(p.elisionMarkerLen())
 		case c == '{':
 			p.index++
-			return p.parseObject()
+			n = p.parseObject()
 		case c == '[':
 			p.index++
-			return p.parseArray()
+			n = p.parseArray()
 		case c == '}':
-			return ""
+			return p.emptyNode()
+		case isInMarkers && p.opts.units != UnitsIgnore && p.currencyPrefixLen() > 0:
+			n = p.parseNumber()
 		case isInMarkers && (bytes.IndexByte([]byte{'"', '\''}, c) != -1 || unicode.IsLetter(rune(c))):
-			return p.parseString()
+			n = p.parseString()
 		case isInMarkers && isASCIIDigitOrSign(c):
-			return p.parseNumber()
+			n = p.parseNumber()
 		}
 
+		if n != nil {
+			if skipped && !n.isEmpty() {
+				n.addRepair(RepairSkippedText)
+			}
+			return n
+		}
+
+		if !isASCIISpace(rune(c)) {
+			skipped = true
+		}
 		p.index++
 	}
 
@@ -214,10 +303,10 @@ func (p *JSONParser) parseJSON() any {
 //
 //	Description:
 //	receiver p
-//	return map[string]any
-func (p *JSONParser) parseObject() map[string]any {
+//	return *Node
+func (p *JSONParser) parseObject() *Node {
 
-	rst := make(map[string]any)
+	rst := &Node{Kind: ObjectNode, Start: p.index - 1}
 	seenKeys := make(map[string]bool)
 
 	var c byte
@@ -239,15 +328,33 @@ func (p *JSONParser) parseObject() map[string]any {
 		p.setMarker("object_key")
 		p.skipWhitespaces()
 
+		if size := p.elisionMarkerLen(); size > 0 {
+			p.index += size
+			p.resetMarker()
+			rst.addRepair(RepairEllipsis)
+			p.skipWhitespaces()
+			if c, b = p.getByte(0); b && c == ',' {
+				p.index++
+			}
+			c, b = p.getByte(0)
+			continue
+		}
+
 		var key string
+		keyStart, keyEnd := p.index, p.index
+		var keyRepairs []RepairFlag
+		missingKey := false
 		_, b = p.getByte(0)
 		for key == "" && b {
 			currentIndex := p.index
-			key = p.parseString().(string)
+			keyNode := p.parseString()
+			key = keyNode.str()
+			keyStart, keyEnd, keyRepairs = keyNode.Start, keyNode.End, keyNode.Repairs
 
 			c, b = p.getByte(0)
 			if key == "" && b && c == ':' {
 				key = "empty_placeholder"
+				missingKey = true
 				break
 			} else if key == "" && p.index == currentIndex {
 				p.index++
@@ -260,6 +367,7 @@ func (p *JSONParser) parseObject() map[string]any {
 			shouldSplit := !p.isCommaSeparatedKey(rollbackIndex)
 			if shouldSplit {
 				p.index = rollbackIndex - 1
+				rst.addRepair(RepairSplitObject)
 				break
 			}
 			// comma-separated duplicate: standard overwrite behavior, continue
@@ -288,26 +396,41 @@ func (p *JSONParser) parseObject() map[string]any {
 		value := p.parseJSON()
 
 		p.resetMarker()
-		if key == "" && value == "" {
+		if key == "" && value.isEmpty() {
 			continue
 		}
-		rst[key] = value
+		if missingKey {
+			value.addRepair(RepairMissingKey)
+		}
+		if p.isElision(value) {
+			rst.addRepair(RepairEllipsis)
+		} else if i := rst.member(key); i >= 0 {
+			rst.Members[i].Value = value
+			value.addRepair(RepairDuplicateKey)
+		} else {
+			rst.Members = append(rst.Members, Member{Key: key, Start: keyStart, End: keyEnd, Repairs: keyRepairs, Value: value})
+		}
 
 		c, b = p.getByte(0)
+		comma := b && c == ','
 		if b && bytes.IndexByte([]byte{',', '\'', '"'}, c) != -1 {
 			p.index++
 		}
 
 		p.skipWhitespaces()
 		c, b = p.getByte(0)
+		if comma && b && c == '}' {
+			rst.addRepair(RepairTrailingComma)
+		}
 	}
 
 	c, b = p.getByte(0)
-	//nolint
-	if b && c != '}' {
+	if !b {
+		rst.addRepair(RepairUnclosedObject)
 	}
 
 	p.index++
+	rst.End = p.pos()
 	return rst
 }
 
@@ -330,10 +453,10 @@ func (p *JSONParser) parseObject() map[string]any {
 //    - If '}' is followed by ',' or '{', array should continue
 //
 //	receiver p
-//	return []any
-func (p *JSONParser) parseArray() []any {
+//	return *Node
+func (p *JSONParser) parseArray() *Node {
 
-	rst := make([]any, 0)
+	rst := &Node{Kind: ArrayNode, Start: p.index - 1}
 
 	var c byte
 	var b bool
@@ -367,7 +490,7 @@ func (p *JSONParser) parseArray() []any {
 					if !nextB {
 						break
 					}
-					if unicode.IsSpace(rune(nextC)) {
+					if isASCIISpace(rune(nextC)) {
 						lookahead++
 						continue
 					}
@@ -388,6 +511,8 @@ func (p *JSONParser) parseArray() []any {
 					// Treat '}' as ']' and end the array
 					p.index++
 					p.resetMarker()
+					rst.addRepair(RepairMismatchedBracket)
+					rst.End = p.pos()
 					return rst
 				}
 			}
@@ -395,25 +520,26 @@ func (p *JSONParser) parseArray() []any {
 
 		value := p.parseJSON()
 
-		if value == nil || value == "" {
+		if value.isEmpty() {
 			break
 		}
 
-		if tc, ok := value.(string); ok && tc == "" {
-			break
-		}
-
-		c, b = p.getByte(-1)
-		if value == "..." && b && c == '.' {
+		if p.isElision(value) {
+			rst.addRepair(RepairEllipsis)
 		} else {
-			rst = append(rst, value)
+			rst.Children = append(rst.Children, value)
 		}
 
+		comma := false
 		c, b = p.getByte(0)
-		for b && (unicode.IsSpace(rune(c)) || c == ',') {
+		for b && (isASCIISpace(rune(c)) || c == ',') {
+			comma = comma || c == ','
 			p.index++
 			c, b = p.getByte(0)
 		}
+		if comma && b && c == ']' {
+			rst.addRepair(RepairTrailingComma)
+		}
 
 		// PR #21: Only break due to '}' when not in array context
 		if p.getMarker() == "object_value" && c == '}' {
@@ -436,15 +562,20 @@ func (p *JSONParser) parseArray() []any {
 	}
 
 	c, b = p.getByte(0)
+	if !b {
+		rst.addRepair(RepairUnclosedArray)
+	}
 	if b && c != ']' {
 		//nolint
 		if c == ',' {
 		}
+		rst.addRepair(RepairMismatchedBracket)
 		p.index--
 	}
 
 	p.index++
 	p.resetMarker()
+	rst.End = p.pos()
 	return rst
 }
 
@@ -453,20 +584,28 @@ func (p *JSONParser) parseArray() []any {
 //	Description:
 //	receiver p
 //	param quotes
-//	return any
-func (p *JSONParser) parseString() any {
+//	return *Node
+func (p *JSONParser) parseString() *Node {
 
-	var missingQuotes, doubledQuotes = false, false
+	var missingQuotes, doubledQuotes, smartQuotes = false, false, false
+	var embeddedQuote, closed = false, false
+	var escapes []RepairFlag
+	var endedAtNewline bool
 	var lStringDelimiter, rStringDelimiter byte = '"', '"'
 
 	var c byte
 	var b bool
 
+	start := p.index
+
 	// If delimiter was set by caller (parseJSON for smart quotes), use it directly
 	if p.rstringDelimiter != 0 {
 		lStringDelimiter = p.rstringDelimiter
 		rStringDelimiter = p.rstringDelimiter
 		p.rstringDelimiter = 0
+		_, sz := utf8.DecodeLastRuneInString(p.container[:p.index])
+		start -= sz
+		smartQuotes = true
 	} else {
 		c, b = p.getByte(0)
 		for b && !isQuoteByte(c) && !unicode.IsLetter(rune(c)) && !isSmartQuoteAt(p.container, p.index, 0) {
@@ -475,8 +614,9 @@ func (p *JSONParser) parseString() any {
 		}
 
 		if !b {
-			return ""
+			return p.emptyNode()
 		}
+		start = p.index
 
 		// Handle smart/typographic quotes — detect before switch since getByte returns first byte only
 		smartQuoteHandled := false
@@ -486,6 +626,7 @@ func (p *JSONParser) parseString() any {
 			rStringDelimiter = asciiQuote
 			lStringDelimiter = asciiQuote
 			smartQuoteHandled = true
+			smartQuotes = true
 		}
 
 		switch {
@@ -495,15 +636,16 @@ func (p *JSONParser) parseString() any {
 			rStringDelimiter = '\''
 		case unicode.IsLetter(rune(c)):
 
+			if p.opts.vocabulary != nil && p.getMarker() != "object_key" {
+				if value := p.parseVocabularyLiteral(); !value.isEmpty() {
+					return value
+				}
+			}
+
 			if bytes.IndexByte([]byte{'t', 'f', 'n'}, byte(unicode.ToLower(rune(c)))) != -1 &&
 				p.getMarker() != "object_key" {
-				value := p.parseBooleanOrNull()
-				if vs, ok := value.(string); !ok {
+				if value := p.parseBooleanOrNull(); !value.isEmpty() {
 					return value
-				} else {
-					if vs != "" {
-						return vs
-					}
 				}
 			}
 
@@ -518,6 +660,7 @@ func (p *JSONParser) parseString() any {
 	// Check for code fence block (```json ... ```) inside a string value
 	if c, b := p.getByte(0); b && c == '`' {
 		if val := p.parseJSONLLMBlock(); val != nil {
+			val.addRepair(RepairCodeFenceValue)
 			return val
 		}
 	}
@@ -540,7 +683,7 @@ func (p *JSONParser) parseString() any {
 			i = 1
 			nextC, nextB = p.getByte(i)
 			// Skip all whitespace characters (space, newline, tab, etc.)
-			for nextB && unicode.IsSpace(rune(nextC)) {
+			for nextB && isASCIISpace(rune(nextC)) {
 				i++
 				nextC, nextB = p.getByte(i)
 			}
@@ -549,7 +692,7 @@ func (p *JSONParser) parseString() any {
 			if nextB && bytes.IndexByte([]byte{',', ']', '}'}, nextC) != -1 {
 				// This is an empty string, skip the closing quote and return
 				p.index++
-				return ""
+				return newScalarNode("", start, p.index)
 			} else if nextB && bytes.IndexByte([]byte{',', ']', '}'}, nextC) == -1 {
 				p.index++
 			}
@@ -561,15 +704,23 @@ func (p *JSONParser) parseString() any {
 	c, b = p.getByte(0)
 
 	for b && c != rStringDelimiter {
+		// A raw newline ends an unclosed string when the next line starts a
+		// new member or only closes the document
+		if (c == '\n' || c == '\r') && !missingQuotes && p.newlineEndsString(rStringDelimiter) {
+			endedAtNewline = true
+			break
+		}
+
 		// Position 4: Check for smart/typographic quote that matches closing delimiter
 		if smartMatch, ok := getSmartQuoteByteAt(p.container, p.index, 0); ok && smartMatch == rStringDelimiter {
 			_, sz := utf8.DecodeRuneInString(p.container[p.index:])
 			p.index += sz
+			closed = true
 			break
 		}
 
 		if missingQuotes {
-			if p.getMarker() == "object_key" && (c == ':' || unicode.IsSpace(rune(c))) {
+			if p.getMarker() == "object_key" && (c == ':' || isASCIISpace(rune(c))) {
 				break
 			} else if p.getMarker() == "object_value" && bytes.IndexByte([]byte{',', '}'}, c) != -1 {
 
@@ -602,35 +753,25 @@ func (p *JSONParser) parseString() any {
 			}
 		}
 
-		rst = append(rst, c)
-		p.index++
-
-		c, b = p.getByte(0)
-
-		if len(rst) > 1 && rst[len(rst)-1] == '\\' {
-
-			rst = rst[:len(rst)-1]
-
-			if bytes.IndexByte([]byte{rStringDelimiter, 't', 'n', 'r', 'b', '\\'}, c) != -1 {
-
-				escapeSeqs := map[byte]byte{
-					't': '\t',
-					'n': '\n',
-					'r': '\r',
-					'b': '\b',
-				}
-
-				if ce, ok := escapeSeqs[c]; ok {
-					rst = append(rst, ce)
-				} else {
-					rst = append(rst, c)
-				}
-
-				p.index++
-				c, b = p.getByte(0)
+		if c == '\\' {
+			var flag RepairFlag
+			if rst, flag = p.parseEscape(rst, rStringDelimiter); flag != "" {
+				escapes = append(escapes, flag)
+			}
+		} else {
+			switch {
+			case missingQuotes:
+			case c == '\n' || c == '\r':
+				escapes = append(escapes, RepairRawNewline)
+			case c < 0x20:
+				escapes = append(escapes, RepairControlCharacter)
 			}
+			rst = append(rst, c)
+			p.index++
 		}
 
+		c, b = p.getByte(0)
+
 		if c == rStringDelimiter {
 			// Special handling for unescaped quotes inside string values (Issue #18)
 			// Check if this quote is actually inside the string content
@@ -640,7 +781,7 @@ func (p *JSONParser) parseString() any {
 				nextC, nextB := p.getByte(i)
 
 				// Skip whitespace after the quote
-				for nextB && unicode.IsSpace(rune(nextC)) {
+				for nextB && isASCIISpace(rune(nextC)) {
 					i++
 					nextC, nextB = p.getByte(i)
 				}
@@ -657,7 +798,7 @@ func (p *JSONParser) parseString() any {
 							if !checkOk {
 								break
 							}
-							if unicode.IsSpace(rune(checkCh)) {
+							if isASCIISpace(rune(checkCh)) {
 								checkIdx++
 								continue
 							}
@@ -696,7 +837,7 @@ func (p *JSONParser) parseString() any {
 					if nc == rStringDelimiter {
 						afterQuote, afterQuoteB := p.getByte(j + 1)
 						k := j + 1
-						for afterQuoteB && unicode.IsSpace(rune(afterQuote)) {
+						for afterQuoteB && isASCIISpace(rune(afterQuote)) {
 							k++
 							afterQuote, afterQuoteB = p.getByte(k)
 						}
@@ -719,7 +860,7 @@ func (p *JSONParser) parseString() any {
 							if !checkOk {
 								break
 							}
-							if unicode.IsSpace(rune(checkCh)) {
+							if isASCIISpace(rune(checkCh)) {
 								checkPos++
 								continue
 							}
@@ -747,6 +888,7 @@ func (p *JSONParser) parseString() any {
 
 				// If best candidate is not current quote, treat current as content
 				if bestIdx > 0 || (bestIdx == 0 && len(candidates) > 0 && candidates[0].pos != 0) {
+					embeddedQuote = true
 					rst = append(rst, c)
 					p.index++
 					c, b = p.getByte(0)
@@ -819,6 +961,7 @@ func (p *JSONParser) parseString() any {
 					}
 
 					if nextB && nextC == '}' {
+						embeddedQuote = true
 						rst = append(rst, c)
 						p.index++
 						c, b = p.getByte(0)
@@ -843,6 +986,7 @@ func (p *JSONParser) parseString() any {
 						}
 
 						if nextC != ':' {
+							embeddedQuote = true
 							rst = append(rst, c)
 							p.index++
 							c, b = p.getByte(0)
@@ -857,20 +1001,90 @@ func (p *JSONParser) parseString() any {
 
 	if b && missingQuotes &&
 		p.getMarker() == "object_key" &&
-		unicode.IsSpace(rune(c)) {
+		isASCIISpace(rune(c)) {
 		p.skipWhitespaces()
 		ci, bi := p.getByte(0)
 		if !bi || bytes.IndexByte([]byte{':', ','}, ci) == -1 {
-			return ""
+			return p.emptyNode()
 		}
 	}
 
 	if !b || c != rStringDelimiter {
 	} else {
 		p.index++
+		closed = true
 	}
 
-	return strings.TrimRightFunc(string(rst), unicode.IsSpace)
+	// Trailing whitespace is only part of the value in a closed string
+	value := string(rst)
+	if !closed || p.getMarker() == "object_key" {
+		value = strings.TrimRightFunc(value, unicode.IsSpace)
+	}
+	n := newScalarNode(value, start, p.pos())
+	switch {
+	case missingQuotes:
+		n.addRepair(RepairMissingQuotes)
+	case smartQuotes:
+		n.addRepair(RepairSmartQuotes)
+	case lStringDelimiter == '\'':
+		n.addRepair(RepairSingleQuotes)
+	}
+	if doubledQuotes {
+		n.addRepair(RepairDoubledQuotes)
+	}
+	if embeddedQuote {
+		n.addRepair(RepairEmbeddedQuote)
+	}
+	for _, flag := range escapes {
+		n.addRepair(flag)
+	}
+	if !closed && !missingQuotes && (!b || endedAtNewline) {
+		n.addRepair(RepairUnclosedString)
+	}
+	return n
+}
+
+// newlineEndsString reports whether the raw newline at p.index ends an
+// unclosed string: the next line starts a "key": member of the enclosing
+// object or a "string" element of the enclosing array, or the rest of the
+// input only closes containers.
+func (p *JSONParser) newlineEndsString(delimiter byte) bool {
+	i := 1
+	c, b := p.getByte(i)
+	for b && isASCIISpace(rune(c)) {
+		i++
+		c, b = p.getByte(i)
+	}
+	if !b {
+		return false
+	}
+
+	if c == delimiter {
+		marker := p.getMarker()
+		if marker != "object_value" && marker != "array" {
+			return false
+		}
+		for i++; ; i++ {
+			if c, b = p.getByte(i); !b || c == '\n' || c == delimiter {
+				break
+			}
+		}
+		if !b || c != delimiter {
+			return false
+		}
+		for i++; ; i++ {
+			if c, b = p.getByte(i); !b || (c != ' ' && c != '\t') {
+				break
+			}
+		}
+		if marker == "array" {
+			return !b || c == ',' || c == ']'
+		}
+		return b && c == ':'
+	}
+
+	rest := strings.TrimLeft(p.container[p.index+i:], "]}, \t\r\n")
+	return rest == "" && (c == ']' || c == '}')
 }
 
 // isASCIIDigitOrSign returns true for bytes that parseNumber actually accepts:
@@ -887,7 +1101,7 @@ func (p *JSONParser) isCommaSeparatedKey(rollbackIndex int) bool {
 	idx := rollbackIndex - 1
 	for idx >= 0 {
 		c := p.container[idx]
-		if !unicode.IsSpace(rune(c)) {
+		if !isASCIISpace(rune(c)) {
 			return c == ','
 		}
 		idx--
@@ -899,10 +1113,21 @@ func (p *JSONParser) isCommaSeparatedKey(rollbackIndex int) bool {
 //
 //	Description:
 //	receiver p
-//	return any
-func (p *JSONParser) parseNumber() any {
+//	return *Node
+func (p *JSONParser) parseNumber() *Node {
 	var rst []byte
 
+	start := p.index
+
+	unit := ""
+	if p.opts.units != UnitsIgnore {
+		if size := p.currencyPrefixLen(); size > 0 {
+			unit = p.container[p.index : p.index+size]
+			p.index += size
+		}
+	}
+	numberStart := p.index
+
 	numberChars := []byte{'0', '1', '2', '3', '4', '5', '6', '7', '8', '9', '-', '.', 'e', 'E', '/', ','}
 
 	var c byte
@@ -919,39 +1144,61 @@ func (p *JSONParser) parseNumber() any {
 		c, b = p.getByte(0)
 	}
 
+	trimmed := false
 	if len(rst) > 1 && bytes.IndexByte([]byte{'-', 'e', 'E', '/', ','}, rst[len(rst)-1]) != -1 {
+		// a trailing ',' is just the separator after the number
+		trimmed = rst[len(rst)-1] != ','
 		rst = rst[:len(rst)-1]
 		p.index--
 	}
 
+	var n *Node
+	localized, isLocalized := parseLocaleNumber(string(rst), p.opts.locale)
 	switch {
 	case len(rst) == 0:
 		// Nothing consumed — advance past this byte so parseJSON makes progress
 		// instead of looping back here forever (issue #23).
 		p.index++
-		return ""
+		return p.emptyNode()
+	case isLocalized:
+		n = newScalarNode(localized, numberStart, p.index)
+		n.addRepair(RepairNumberLocale)
 	case bytes.IndexByte(rst, ',') != -1:
-		return string(rst)
+		n = newScalarNode(string(rst), numberStart, p.index)
+		n.addRepair(RepairNumberAsString)
 	case bytes.IndexByte(rst, '.') != -1,
 		bytes.IndexByte(rst, 'e') != -1,
 		bytes.IndexByte(rst, 'E') != -1:
 		r, _ := strconv.ParseFloat(string(rst), 32)
-		return r
+		n = newScalarNode(r, numberStart, p.index)
 	case string(rst) == "-":
 		// Avoid infinite recursion by returning 0 instead
-		return 0
+		n = newScalarNode(0, numberStart, p.index)
+	default:
+		r, _ := strconv.Atoi(string(rst))
+		n = newScalarNode(r, numberStart, p.index)
 	}
 
-	r, _ := strconv.Atoi(string(rst))
-	return r
+	if trimmed {
+		n.addRepair(RepairNumberTrimmed)
+	}
+	if p.opts.units != UnitsIgnore {
+		if suffix := p.parseNumberUnit(); suffix != "" {
+			unit += suffix
+		}
+		if unit != "" {
+			return p.applyUnitPolicy(n, unit, start)
+		}
+	}
+	return n
 }
 
 // parseBooleanOrNull
 //
 //	Description:
 //	receiver p
-//	return any
-func (p *JSONParser) parseBooleanOrNull() any {
+//	return *Node
+func (p *JSONParser) parseBooleanOrNull() *Node {
 
 	startingIndex := p.index
 
@@ -997,17 +1244,54 @@ func (p *JSONParser) parseBooleanOrNull() any {
 		}
 
 		if i == len(gs.va) {
-			return gs.vt
+			n := newScalarNode(gs.vt, startingIndex, p.index)
+			if p.container[startingIndex:p.index] != gs.va {
+				n.addRepair(RepairLiteralCase)
+			}
+			return n
 		}
 	}
 
 	p.index = startingIndex
-	return ""
+	return p.emptyNode()
+}
+
+// parseVocabularyLiteral reads the unquoted word at p.index and returns the
+// value WithLiteralVocabulary maps it to, or an empty node if it maps to
+// nothing. true, false and null are left to parseBooleanOrNull.
+func (p *JSONParser) parseVocabularyLiteral() *Node {
+	end := p.index
+	for end < len(p.container) && strings.IndexByte(",:]}\"' \t\r\n", p.container[end]) == -1 {
+		end++
+	}
+	word := p.container[p.index:end]
+
+	for _, literal := range []string{"true", "false", "null"} {
+		if strings.EqualFold(word, literal) {
+			return p.emptyNode()
+		}
+	}
+	v, ok := p.opts.vocabulary[strings.ToLower(word)]
+	if !ok {
+		return p.emptyNode()
+	}
+	// the word must be the whole value, not the start of "no way"
+	next := end
+	for next < len(p.container) && isASCIISpace(rune(p.container[next])) {
+		next++
+	}
+	if next < len(p.container) && strings.IndexByte(",]}", p.container[next]) == -1 {
+		return p.emptyNode()
+	}
+	n := newScalarNode(v, p.index, end)
+	n.addRepair(RepairLiteralVocabulary)
+	p.index = end
+	return n
 }
 
 // parseJSONLLMBlock attempts to parse a ```json ... ``` code fence block.
 // Returns the parsed JSON value if successful, or nil if not a valid code fence.
-func (p *JSONParser) parseJSONLLMBlock() any {
+func (p *JSONParser) parseJSONLLMBlock() *Node {
 	// Check for ```json prefix (7 bytes)
 	if p.index+7 > len(p.container) {
 		return nil
@@ -1028,6 +1312,16 @@ func (p *JSONParser) parseJSONLLMBlock() any {
 	return nil
 }
 
+// emptyNode returns the "nothing parsed" sentinel at the current position.
+func (p *JSONParser) emptyNode() *Node {
+	return newScalarNode("", p.pos(), p.pos())
+}
+
+// pos returns the current index clamped to the container, for node offsets.
+func (p *JSONParser) pos() int {
+	return min(p.index, len(p.container))
+}
+
 // currentChar
 //
 //	Description:
@@ -1051,7 +1345,7 @@ func (p *JSONParser) skipWhitespaces() {
 	var b bool
 	c, b = p.getByte(0)
 
-	for b && unicode.IsSpace(rune(c)) {
+	for b && isASCIISpace(rune(c)) {
 		p.index++
 		c, b = p.getByte(0)
 	}
@@ -403,6 +403,11 @@ func Test_RepairJSON(t *testing.T) {
 			in:   `{"key":"value","key":"value2"}`,
 			want: `{"key":"value2"}`,
 		},
+		// null elements must not end a repaired array
+		{
+			in:   `[null, 1, null`,
+			want: `[null,1,null]`,
+		},
 	}
 
 	caseNo := 1
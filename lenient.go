@@ -0,0 +1,63 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+)
+
+// Lenient
+//
+//	Description: a struct field type that decodes into Value either from
+//	valid JSON for T or from a JSON string holding broken JSON for T, which is
+//	repaired first. Broken JSON outside a string cannot be reached this way,
+//	since encoding/json rejects the enclosing document before any field is
+//	decoded.
+//
+//	type Envelope struct {
+//		ID   string                    `json:"id"`
+//		Args jsonrepair.Lenient[Args]  `json:"args"`
+//	}
+type Lenient[T any] struct {
+	Value T
+	// Repaired is set when Value was decoded from repaired JSON.
+	Repaired bool
+}
+
+// UnmarshalJSON
+//
+//	Description: implements json.Unmarshaler
+//	receiver l
+//	param data
+//	return error
+func (l *Lenient[T]) UnmarshalJSON(data []byte) error {
+	var v T
+	err := json.Unmarshal(data, &v)
+	if err == nil {
+		l.Value, l.Repaired = v, false
+		return nil
+	}
+
+	var s string
+	if json.Unmarshal(data, &s) != nil {
+		return err
+	}
+	dst, errR := RepairJSON(s)
+	if errR != nil {
+		return errR
+	}
+	var repaired T
+	if errR = json.Unmarshal([]byte(dst), &repaired); errR != nil {
+		return errR
+	}
+	l.Value, l.Repaired = repaired, true
+	return nil
+}
+
+// MarshalJSON
+//
+//	Description: implements json.Marshaler, writing Value as plain JSON
+//	receiver l
+//	return []byte
+//	return error
+func (l Lenient[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(l.Value)
+}
@@ -0,0 +1,77 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type lenientArgs struct {
+	City string   `json:"city"`
+	Days int      `json:"days"`
+	Tags []string `json:"tags"`
+}
+
+type lenientEnvelope struct {
+	ID   string               `json:"id"`
+	Args Lenient[lenientArgs] `json:"args"`
+}
+
+// Test_Lenient
+//
+//	Description:
+//	param t
+func Test_Lenient(t *testing.T) {
+	tests := []struct {
+		in       string
+		want     lenientArgs
+		repaired bool
+	}{
+		{
+			in:   `{"id": "1", "args": {"city": "Paris", "days": 3, "tags": ["a"]}}`,
+			want: lenientArgs{City: "Paris", Days: 3, Tags: []string{"a"}},
+		},
+		{
+			in:       `{"id": "2", "args": "{\"city\": \"Paris\", \"days\": 3, \"tags\": [\"a\", \"b\","}`,
+			want:     lenientArgs{City: "Paris", Days: 3, Tags: []string{"a", "b"}},
+			repaired: true,
+		},
+		{
+			in:       `{"id": "3", "args": "{'city': 'Oslo', days: 2}"}`,
+			want:     lenientArgs{City: "Oslo", Days: 2},
+			repaired: true,
+		},
+		{
+			in:       `{"id": "4", "args": "{\"city\": \"Rome\"}"}`,
+			want:     lenientArgs{City: "Rome"},
+			repaired: true,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			var env lenientEnvelope
+			if err := json.Unmarshal([]byte(tt.in), &env); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(env.Args.Value, tt.want) || env.Args.Repaired != tt.repaired {
+				t.Errorf("json.Unmarshal() got = %+v, want %+v (repaired %v)", env.Args, tt.want, tt.repaired)
+			}
+		})
+	}
+
+	var env lenientEnvelope
+	if err := json.Unmarshal([]byte(`{"id": "5", "args": 42}`), &env); err == nil {
+		t.Errorf("json.Unmarshal() expected an error for a number")
+	}
+
+	env = lenientEnvelope{ID: "6", Args: Lenient[lenientArgs]{Value: lenientArgs{City: "Lima"}}}
+	bs, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":"6","args":{"city":"Lima","days":0,"tags":null}}`; string(bs) != want {
+		t.Errorf("json.Marshal() got = %s, want %s", bs, want)
+	}
+}
@@ -0,0 +1,58 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_MultilineStrings
+//
+//	Description:
+//	param t
+func Test_RepairJSON_MultilineStrings(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "{\"lang\": \"python\", \"code\": \"def add(a, b):\n\treturn a + b\n\", \"ok\": true",
+			want: `{"lang":"python","code":"def add(a, b):\n\treturn a + b\n","ok":true}`,
+		},
+		{
+			in:   "{\"code\": \"function f() {\n  return \"x\";\n}\", \"lang\": \"js\"}",
+			want: `{"code":"function f() {\n  return \"x\";\n}","lang":"js"}`,
+		},
+		{
+			in:   "{\"poem\": \"Roses are red,\r\nViolets are blue\"}",
+			want: `{"poem":"Roses are red,\r\nViolets are blue"}`,
+		},
+		{
+			in:   "{\"title\": \"unclosed\n  \"body\": \"text\"\n}",
+			want: `{"title":"unclosed","body":"text"}`,
+		},
+		{
+			in:   "{\"a\": \"one\n\"b\": 2}",
+			want: `{"a":"one","b":2}`,
+		},
+		{
+			in:   "[\"first\n\"second\", \"third\"]",
+			want: `["first","second","third"]`,
+		},
+		{
+			in:   "{\"list\": [\"a\", \"b\nc\"], \"end\": \"cut\n}",
+			want: `{"list":["a","b\nc"],"end":"cut"}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %q", got, tt.want, tt.in)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package jsonrepair
+
+import "strings"
+
+// NestedJSONMode
+//
+//	Description: what to do with string values holding JSON, see
+//	WithNestedJSON
+type NestedJSONMode int
+
+const (
+	// NestedJSONOff leaves string values alone (default).
+	NestedJSONOff NestedJSONMode = iota
+	// NestedJSONParse replaces the string with the repaired value it holds.
+	NestedJSONParse
+	// NestedJSONReencode repairs the JSON in the string and stores it back
+	// as a compact JSON string. Strings holding valid JSON are kept as is.
+	NestedJSONReencode
+)
+
+// expandNestedJSON repairs the JSON held by string values under n, recursing
+// into the repaired values, and reports whether anything changed.
+//
+// Offsets inside a parsed value are relative to the content of its string.
+func expandNestedJSON(n *Node, o *options) bool {
+	changed := false
+	Walk(n, func(_ string, e *Node) bool {
+		if e.Kind != StringNode {
+			return true
+		}
+		s := strings.TrimSpace(e.str())
+		if s == "" || (s[0] != '{' && s[0] != '[') {
+			return true
+		}
+		if s[0] == '{' && s != "{}" && !strings.Contains(s, ":") {
+			// an object without a single member is prose in braces
+			return true
+		}
+
+		dst, inner, _, err := repairDocument(s, true, o)
+		if err != nil || inner == nil || !isNestedJSON(inner) {
+			return true
+		}
+
+		switch o.nested {
+		case NestedJSONParse:
+			start, end := e.Start, e.End
+			repairs, comments := e.Repairs, e.Comments
+			*e = *inner
+			e.Start, e.End = start, end
+			e.Comments = append(comments, e.Comments...)
+			for _, f := range repairs {
+				e.addRepair(f)
+			}
+		case NestedJSONReencode:
+			if !hasRepairs(inner) {
+				return true
+			}
+			e.Value = dst
+		}
+		e.addRepair(RepairNestedJSON)
+		changed = true
+		return false
+	})
+	return changed
+}
+
+// isNestedJSON reports whether the repaired tree looks like JSON the model
+// meant to write, rather than prose in brackets such as "[citation needed]":
+// no value in it had to be quoted.
+func isNestedJSON(root *Node) bool {
+	ok := root.Kind == ObjectNode || root.Kind == ArrayNode
+	Walk(root, func(_ string, n *Node) bool {
+		if n.HasRepair(RepairMissingQuotes) {
+			ok = false
+		}
+		return ok
+	})
+	return ok
+}
+
+// hasRepairs reports whether any node or key under root was repaired.
+func hasRepairs(root *Node) bool {
+	repaired := false
+	Walk(root, func(_ string, n *Node) bool {
+		repaired = repaired || len(n.Repairs) > 0
+		for _, m := range n.Members {
+			repaired = repaired || len(m.Repairs) > 0
+		}
+		return !repaired
+	})
+	return repaired
+}
@@ -0,0 +1,75 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_NestedJSON
+//
+//	Description:
+//	param t
+func Test_RepairJSON_NestedJSON(t *testing.T) {
+	tests := []struct {
+		in   string
+		mode NestedJSONMode
+		want string
+	}{
+		{
+			in:   `{"name": "get_weather", "arguments": "{\"city\": \"Paris\", \"days\": 3"}`,
+			mode: NestedJSONParse,
+			want: `{"name":"get_weather","arguments":{"city":"Paris","days":3}}`,
+		},
+		{
+			in:   `{"name": "get_weather", "arguments": "{\"city\": \"Paris\", \"days\": 3"}`,
+			mode: NestedJSONReencode,
+			want: `{"name":"get_weather","arguments":"{\"city\":\"Paris\",\"days\":3}"}`,
+		},
+		{
+			in:   `{"arguments": "{\"city\": \"Paris\"}"}`,
+			mode: NestedJSONReencode,
+			want: `{"arguments":"{\"city\": \"Paris\"}"}`,
+		},
+		{
+			in:   `[{'args': '{"filter": "{\\"tags\\": [\\"a\\", \\"b\\",]}"}'}]`,
+			mode: NestedJSONParse,
+			want: `[{"args":{"filter":{"tags":["a","b"]}}}]`,
+		},
+		{
+			in:   `{"note": "[citation needed]", "list": "[1, 2, 3]", "text": "{not json"}`,
+			mode: NestedJSONParse,
+			want: `{"note":"[citation needed]","list":[1,2,3],"text":"{not json"}`,
+		},
+		{
+			in:   `{"a": 12345678901234567890, "f": 1.0, "e": 1e3, "b": "{\"c\":1}"}`,
+			mode: NestedJSONParse,
+			want: `{"a":12345678901234567890,"f":1.0,"e":1e3,"b":{"c":1}}`,
+		},
+		{
+			in:   `{"arguments": "{\"a\": 1"}`,
+			mode: NestedJSONOff,
+			want: `{"arguments":"{\"a\": 1"}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, WithNestedJSON(tt.mode))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+
+	root, err := ParseAST(`{"arguments": "{\"a\": [1, 2"}`, WithNestedJSON(NestedJSONParse))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if args := root.Members[0].Value; args.Kind != ObjectNode || !args.HasRepair(RepairNestedJSON) ||
+		!args.Members[0].Value.HasRepair(RepairUnclosedArray) {
+		t.Errorf("ParseAST() arguments = %+v", args)
+	}
+}
@@ -1,6 +1,7 @@
 package jsonrepair
 
 import (
+	"encoding/json"
 	"strings"
 	"unicode/utf8"
 )
@@ -8,8 +9,14 @@ import (
 // normalizeInput preprocesses the input string to handle common variations
 // found in LLM output, especially from Chinese/multilingual models:
 //
-//   - Full-width structural chars (｛｝［］：，) → ASCII equivalents
+//   - Invisible and confusable characters outside strings (NBSP, U+3000,
+//     zero-width spaces, U+2212, full-width digits) → ASCII, see
+//     WithNormalization
+//   - Full-width structural chars (｛｝［］：，) → ASCII equivalents, and CJK
+//     quotes, brackets and separators in key or value position
 //   - Code fences (```json ... ```) stripped from start/end
+//   - Python and JavaScript literals translated, with WithPythonLiterals
+//     and WithJavaScriptLiterals
 //   - Line comments (// ..., # ...) and block comments (/* ... */)
 //
 // NOTE: Quote variants (curly/typographic quotes) are NOT normalized here
@@ -17,17 +24,57 @@ import (
 // literal quote characters (e.g. URLs with embedded quotes). Instead,
 // the parser itself recognizes curly/typographic quotes as string
 // delimiters on the fly.
-func normalizeInput(src string) string {
+//
+// The returned flags record which of the steps changed the input, and the
+// stripped comments are returned for the AST.
+func normalizeInput(src string, o *options) (string, []RepairFlag, []comment) {
+	var flags []RepairFlag
+
+	// Step 0: Normalize invisible and confusable characters outside strings
+	if len(o.normalization) > 0 {
+		if s := normalizeCharacters(src, o.normalization); s != src {
+			flags = append(flags, RepairNormalizedCharacters)
+			src = s
+		}
+	}
+
 	// Step 1: Normalize full-width structural characters
-	src = normalizePunctuation(src)
+	if s := normalizeCJK(normalizePunctuation(src)); s != src {
+		flags = append(flags, RepairFullWidthPunctuation)
+		src = s
+	}
 
 	// Step 2: Strip code fences
-	src = stripCodeFences(src)
+	trimmed := strings.TrimSpace(src)
+	if src = stripCodeFences(src, o.fenceLanguages); src != trimmed {
+		flags = append(flags, RepairCodeFence)
+	}
 
-	// Step 3: Strip comments
-	src = stripComments(src)
+	// Step 3: Translate Python and JavaScript literals before comments,
+	// since comment markers and quotes inside triple-quoted or template
+	// strings would confuse stripComments. Valid JSON needs neither.
+	valid := (o.python || o.javascript) && json.Valid([]byte(src))
+	if o.python && !valid {
+		if s := translatePython(src); s != src {
+			flags = append(flags, RepairPythonLiterals)
+			src = s
+		}
+	}
+	if o.javascript && !valid {
+		if s := translateJavaScript(src, o.jsValues); s != src {
+			flags = append(flags, RepairJavaScriptLiterals)
+			src = s
+		}
+	}
+
+	// Step 4: Strip comments
+	s, comments := stripComments(src)
+	if len(comments) > 0 {
+		flags = append(flags, RepairComments)
+		src = s
+	}
 
-	return src
+	return src, flags, comments
 }
 
 // normalizePunctuation replaces full-width punctuation with ASCII equivalents.
@@ -64,6 +111,298 @@ func normalizePunctuation(s string) string {
 	return sb.String()
 }
 
+// defaultNormalization is the table normalizeCharacters applies unless
+// WithNormalization replaces it.
+var defaultNormalization = map[rune]string{
+	// spaces
+	'\u00a0': " ", // NO-BREAK SPACE
+	'\u1680': " ", // OGHAM SPACE MARK
+	'\u2000': " ", '\u2001': " ", '\u2002': " ", '\u2003': " ", '\u2004': " ", '\u2005': " ",
+	'\u2006': " ", '\u2007': " ", '\u2008': " ", '\u2009': " ", '\u200a': " ",
+	'\u2028': "\n", // LINE SEPARATOR
+	'\u2029': "\n", // PARAGRAPH SEPARATOR
+	'\u202f': " ",  // NARROW NO-BREAK SPACE
+	'\u205f': " ",  // MEDIUM MATHEMATICAL SPACE
+	'\u3000': " ",  // IDEOGRAPHIC SPACE
+
+	// invisible characters
+	'\u00ad': "", // SOFT HYPHEN
+	'\u200b': "", // ZERO WIDTH SPACE
+	'\u200c': "", // ZERO WIDTH NON-JOINER
+	'\u200d': "", // ZERO WIDTH JOINER
+	'\u2060': "", // WORD JOINER
+	'\ufeff': "", // ZERO WIDTH NO-BREAK SPACE (BOM)
+
+	// signs and digits
+	'\u2212': "-", // MINUS SIGN
+	'\ufe63': "-", // SMALL HYPHEN-MINUS
+	'\uff0d': "-", // FULLWIDTH HYPHEN-MINUS
+	'\uff0b': "+", // FULLWIDTH PLUS SIGN
+	'\uff0e': ".", // FULLWIDTH FULL STOP
+	'\uff10': "0", '\uff11': "1", '\uff12': "2", '\uff13': "3", '\uff14': "4",
+	'\uff15': "5", '\uff16': "6", '\uff17': "7", '\uff18': "8", '\uff19': "9",
+}
+
+// normalizeCharacters replaces the characters in table outside string
+// literals, leaving string contents untouched.
+func normalizeCharacters(s string, table map[rune]string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	// isBlank reports whether r reads as whitespace once normalized
+	isBlank := func(r rune) bool {
+		v, ok := table[r]
+		return isASCIISpace(r) || ok && strings.TrimSpace(v) == ""
+	}
+
+	for i := 0; i < len(s); {
+		if end := stringLiteralEnd(s, i, isBlank); end > i {
+			sb.WriteString(s[i:end])
+			i = end
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if v, ok := table[r]; ok {
+			sb.WriteString(v)
+		} else {
+			sb.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+
+	return sb.String()
+}
+
+// stringLiteralEnd returns the offset just past the string literal starting
+// at s[i], or i if no string starts there. ASCII-quoted strings end the way
+// stripComments ends them: at a quote followed by a structural character or
+// a matching quote (or CJK 】、。), skipping characters for which isBlank is
+// true.
+// Smart-quoted strings end at the next smart quote of the same kind.
+func stringLiteralEnd(s string, i int, isBlank func(rune) bool) int {
+	r, size := utf8.DecodeRuneInString(s[i:])
+	if r == '"' || r == '\'' {
+		delim := s[i]
+		for j := i + 1; j < len(s); j++ {
+			switch s[j] {
+			case '\\':
+				j++
+			case delim:
+				k := j + 1
+				for k < len(s) {
+					nr, nsize := utf8.DecodeRuneInString(s[k:])
+					if !isBlank(nr) {
+						break
+					}
+					k += nsize
+				}
+				if k >= len(s) || strings.IndexByte(",}]:", s[k]) != -1 || s[k] == delim {
+					return j + 1
+				}
+				if nr, _ := utf8.DecodeRuneInString(s[k:]); nr == '】' || nr == '、' || nr == '。' {
+					return j + 1
+				}
+			}
+		}
+		return len(s)
+	}
+
+	if quote := asciiQuoteForSmart(r); quote != 0 {
+		for j := i + size; j < len(s); {
+			nr, nsize := utf8.DecodeRuneInString(s[j:])
+			j += nsize
+			if asciiQuoteForSmart(nr) == quote {
+				return j
+			}
+		}
+		return len(s)
+	}
+	return i
+}
+
+// isASCIISpace reports whether r is JSON whitespace.
+func isASCIISpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r'
+}
+
+// cjkQuotes maps the CJK corner and title brackets used as string
+// delimiters to their closing brackets.
+var cjkQuotes = map[rune]rune{
+	'「': '」',
+	'『': '』',
+	'《': '》',
+}
+
+// normalizeCJK rewrites CJK punctuation used as JSON syntax, only where it
+// stands in key or value position outside strings:
+//
+//   - 「」, 『』 and 《》 around a key or value → a double-quoted string
+//   - 【】 around a value → an array
+//   - 、 after a value → ',', in an object only before the next key; a
+//     member value such as 「x」、「y」 is kept as written, since rewriting
+//     it would split it into two values and lose one
+//   - 。 after a value, before ',', '}', ']' or the end → dropped
+func normalizeCJK(s string) string {
+	out := make([]byte, 0, len(s))
+
+	// last is the last non-space byte written, to tell key and value
+	// positions from running text
+	var last byte
+	write := func(v string) {
+		out = append(out, v...)
+		if t := strings.TrimRight(v, " \t\r\n"); t != "" {
+			last = t[len(t)-1]
+		}
+	}
+	startsValue := func() bool {
+		return last == 0 || strings.IndexByte("{[,:", last) != -1
+	}
+	endsValue := func() bool {
+		if last == 0 {
+			return false
+		}
+		if strings.IndexByte("\"]}", last) != -1 || last >= '0' && last <= '9' {
+			return true
+		}
+		t := strings.TrimRight(string(out), " \t\r\n")
+		return strings.HasSuffix(t, "true") || strings.HasSuffix(t, "false") || strings.HasSuffix(t, "null")
+	}
+
+	// containers holds the open '{' and '[' (or 【); value and valueOut are
+	// where the current member value starts in s and out, so that a value
+	// whose rewrite would split it can be put back as written
+	var containers []byte
+	value, valueOut := -1, 0
+
+	brackets := 0
+	for i := 0; i < len(s); {
+		if end := stringLiteralEnd(s, i, isASCIISpace); end > i {
+			write(s[i:end])
+			i = end
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case cjkQuotes[r] != 0 && startsValue():
+			if end := cjkQuoteEnd(s, i+size, r, cjkQuotes[r]); end >= 0 {
+				write(quoteJSONString(s[i+size : end]))
+				_, closeSize := utf8.DecodeRuneInString(s[end:])
+				i = end + closeSize
+				continue
+			}
+			write(s[i : i+size])
+		case r == '【' && startsValue():
+			brackets++
+			containers = append(containers, '[')
+			write("[")
+		case r == '】' && brackets > 0:
+			brackets--
+			containers = popContainer(containers)
+			write("]")
+		case r == '、' && endsValue() && len(containers) > 0 && containers[len(containers)-1] == '{' &&
+			!keyFollows(s, i+size) && value >= 0:
+			// 「x」、「y」 as a member value is text, not two values
+			end := value
+			for end < len(s) && strings.IndexByte(",}\n", s[end]) == -1 {
+				end++
+			}
+			out = out[:valueOut]
+			write(s[value:end])
+			i, value = end, -1
+			continue
+		case r == '、' && endsValue():
+			write(",")
+		case r == '。' && endsValue():
+			j := i + size
+			for j < len(s) && isASCIISpace(rune(s[j])) {
+				j++
+			}
+			if j < len(s) && strings.IndexByte(",}]", s[j]) == -1 && !strings.HasPrefix(s[j:], "】") {
+				write(s[i : i+size])
+			}
+		default:
+			switch s[i] {
+			case '{', '[':
+				containers = append(containers, s[i])
+			case '}', ']':
+				containers = popContainer(containers)
+			case ':':
+				value, valueOut = i+size, len(out)+size
+			case ',':
+				value = -1
+			}
+			write(s[i : i+size])
+		}
+		i += size
+	}
+
+	return string(out)
+}
+
+// popContainer drops the innermost container of containers, if any.
+func popContainer(containers []byte) []byte {
+	if len(containers) == 0 {
+		return containers
+	}
+	return containers[:len(containers)-1]
+}
+
+// keyFollows reports whether an object key, quoted or not, followed by ':'
+// starts at s[i] after spaces.
+func keyFollows(s string, i int) bool {
+	for i < len(s) && isASCIISpace(rune(s[i])) {
+		i++
+	}
+	if i >= len(s) {
+		return false
+	}
+	r, size := utf8.DecodeRuneInString(s[i:])
+	switch {
+	case r == '"' || r == '\'':
+		i = stringLiteralEnd(s, i, isASCIISpace)
+	case cjkQuotes[r] != 0:
+		end := cjkQuoteEnd(s, i+size, r, cjkQuotes[r])
+		if end < 0 {
+			return false
+		}
+		_, closeSize := utf8.DecodeRuneInString(s[end:])
+		i = end + closeSize
+	default:
+		for i < len(s) && (s[i] == '_' || s[i] >= 0x80 || s[i] >= 'a' && s[i] <= 'z' ||
+			s[i] >= 'A' && s[i] <= 'Z' || s[i] >= '0' && s[i] <= '9') {
+			i++
+		}
+	}
+	for i < len(s) && isASCIISpace(rune(s[i])) {
+		i++
+	}
+	return i < len(s) && s[i] == ':'
+}
+
+// cjkQuoteEnd returns the offset of the closing bracket matching an opening
+// bracket before s[i], counting nested pairs, or -1 if it is not closed on
+// the same line.
+func cjkQuoteEnd(s string, i int, open, close rune) int {
+	depth := 0
+	for j := i; j < len(s); {
+		r, size := utf8.DecodeRuneInString(s[j:])
+		switch {
+		case r == '\n':
+			return -1
+		case r == open:
+			depth++
+		case r == close && depth == 0:
+			return j
+		case r == close:
+			depth--
+		}
+		j += size
+	}
+	return -1
+}
+
 // isQuoteByte returns true if the byte is an ASCII quote character.
 func isQuoteByte(c byte) bool {
 	return c == '"' || c == '\''
@@ -99,15 +438,16 @@ func asciiQuoteForSmart(r rune) byte {
 }
 
 // stripCodeFences removes ```json ... ``` wrappers that LLMs commonly
-// wrap their JSON output in. Handles both prefix and suffix fences.
-func stripCodeFences(s string) string {
+// wrap their JSON output in. Handles both prefix and suffix fences; the info
+// string of the opening fence is dropped when it is one of languages.
+func stripCodeFences(s string, languages []string) string {
 	s = strings.TrimSpace(s)
 
 	// Strip leading fence: ```json, ```, ```JSON, etc.
-	for _, prefix := range []string{"```json", "```JSON", "```"} {
-		if strings.HasPrefix(s, prefix) {
-			s = s[len(prefix):]
-			break
+	if strings.HasPrefix(s, "```") {
+		s = s[3:]
+		if lang := fenceLanguage(s); lang != "" && isFenceLanguage(lang, languages) {
+			s = s[len(lang):]
 		}
 	}
 
@@ -125,10 +465,14 @@ func stripCodeFences(s string) string {
 // followed by a structural character (, } ] :), a space then structural,
 // or another matching quote (for empty strings / doubled quotes).
 // This avoids breaking strings with unescaped quotes inside (Issue #18).
-func stripComments(s string) string {
+//
+// Every removed comment is returned with its position in the output.
+func stripComments(s string) (string, []comment) {
 	var sb strings.Builder
 	sb.Grow(len(s))
 
+	var comments []comment
+
 	i := 0
 	inString := false
 	var stringDelim byte
@@ -166,12 +510,15 @@ func stripComments(s string) string {
 			continue
 		}
 
+		start := i
+
 		// Line comment: //
 		if c == '/' && i+1 < len(s) && s[i+1] == '/' {
 			i += 2
 			for i < len(s) && s[i] != '\n' && s[i] != '\r' {
 				i++
 			}
+			comments = append(comments, comment{Text: s[start:i], Pos: sb.Len()})
 			continue
 		}
 
@@ -185,6 +532,7 @@ func stripComments(s string) string {
 				}
 				i++
 			}
+			comments = append(comments, comment{Text: s[start:min(i, len(s))], Pos: sb.Len()})
 			continue
 		}
 
@@ -193,6 +541,7 @@ func stripComments(s string) string {
 			for i < len(s) && s[i] != '\n' && s[i] != '\r' {
 				i++
 			}
+			comments = append(comments, comment{Text: s[start:i], Pos: sb.Len()})
 			continue
 		}
 
@@ -200,7 +549,14 @@ func stripComments(s string) string {
 		i++
 	}
 
-	return sb.String()
+	return sb.String(), comments
+}
+
+// comment is a comment removed by stripComments. Pos is the offset in the
+// stripped text where it was.
+type comment struct {
+	Text string
+	Pos  int
 }
 
 // getSmartQuoteByteAt checks if the byte at position index+offset in the
@@ -0,0 +1,68 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_Normalization
+//
+//	Description:
+//	param t
+func Test_RepairJSON_Normalization(t *testing.T) {
+	custom := DefaultNormalization()
+	custom['…'] = ""
+
+	tests := []struct {
+		in   string
+		opts []Option
+		want string
+	}{
+		{
+			in:   "{\u00a0\"a\":\u30001, \"b\": [1,\u00a02]\u00a0}",
+			want: `{"a":1,"b":[1,2]}`,
+		},
+		{
+			in:   "{\u200b\"key\u200b\": \"zero\u200bwidth\u00a0kept\",\ufeff \"n\": 1\u200d}",
+			want: "{\"key\u200b\":\"zero\u200bwidth\u00a0kept\",\"n\":1}",
+		},
+		{
+			in:   "{\"temp\": \u22125, \"count\": １２３, \"ratio\": ０．５, \"label\": \"\u2212１\"}",
+			want: "{\"temp\":-5,\"count\":123,\"ratio\":0.5,\"label\":\"\u2212１\"}",
+		},
+		{
+			in:   "{“text”: “a\u00a0b”,\u00a0\"c\": 1}",
+			want: "{\"text\":\"a\u00a0b\",\"c\":1}",
+		},
+		{
+			in:   "{\"a\": \"x\"\u00a0, \"b\": \"it's\u00a0ok\"}",
+			want: "{\"a\":\"x\",\"b\":\"it's\u00a0ok\"}",
+		},
+		{
+			in:   "[1, 2…]",
+			opts: []Option{WithNormalization(custom)},
+			want: `[1,2]`,
+		},
+		{
+			in:   "{\"n\": \u22125}",
+			opts: []Option{WithNormalization(nil)},
+			want: "{\"n\":\"\u22125\"}",
+		},
+		{
+			in:   "{\"a\": 1, voilà: 2, \"b\": café ok}",
+			want: `{"a":1,"voilà":2,"b":"café ok"}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %q", got, tt.want, tt.in)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NumberLocale
+//
+//	Description: the decimal and digit group separators of unquoted numbers,
+//	see WithNumberLocale
+type NumberLocale struct {
+	Decimal byte
+	Group   byte
+}
+
+var (
+	// LocaleEnglish reads 1,234.5 as 1234.5.
+	LocaleEnglish = NumberLocale{Decimal: '.', Group: ','}
+	// LocaleEuropean reads 1.234,5 as 1234.5 and 3,14 as 3.14.
+	LocaleEuropean = NumberLocale{Decimal: ',', Group: '.'}
+)
+
+// UnitPolicy
+//
+//	Description: what to do with a unit, currency or percent sign written
+//	next to an unquoted number, see WithUnitPolicy
+type UnitPolicy int
+
+const (
+	// UnitsIgnore keeps the default behavior: the number is parsed and the
+	// unit is left to the rest of the repair logic.
+	UnitsIgnore UnitPolicy = iota
+	// UnitsNumber keeps the number and drops the unit: 10kg → 10.
+	UnitsNumber
+	// UnitsString keeps the token as a string: 10kg → "10kg".
+	UnitsString
+	// UnitsSplit turns the token into an object: 10kg → {"value":10,"unit":"kg"}.
+	UnitsSplit
+)
+
+// parseLocaleNumber converts a number written with the separators of l.
+// It only succeeds when the separators change how s reads, so plain JSON
+// numbers are left to parseNumber. Digit groups must be three digits long.
+func parseLocaleNumber(s string, l NumberLocale) (any, bool) {
+	if l.Decimal == 0 || strings.ContainsAny(s, "eE") {
+		return nil, false
+	}
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, frac, hasFrac := strings.Cut(s, string(l.Decimal))
+	if hasFrac && (frac == "" || strings.Trim(frac, "0123456789") != "") {
+		return nil, false
+	}
+
+	grouped := l.Group != 0 && strings.IndexByte(intPart, l.Group) != -1
+	if grouped {
+		groups := strings.Split(intPart, string(l.Group))
+		if len(groups[0]) == 0 || len(groups[0]) > 3 {
+			return nil, false
+		}
+		for i, g := range groups {
+			if (i > 0 && len(g) != 3) || strings.Trim(g, "0123456789") != "" {
+				return nil, false
+			}
+		}
+		intPart = strings.Join(groups, "")
+	}
+	if intPart == "" || strings.Trim(intPart, "0123456789") != "" {
+		return nil, false
+	}
+	if !grouped && (!hasFrac || l.Decimal == '.') {
+		return nil, false
+	}
+
+	if !hasFrac {
+		if v, err := strconv.Atoi(sign + intPart); err == nil {
+			return v, true
+		}
+		return nil, false
+	}
+	v, err := strconv.ParseFloat(sign+intPart+"."+frac, 64)
+	return v, err == nil
+}
+
+// isUnitRune reports whether r can be part of a unit written after a number:
+// letters, currency symbols and %, ‰, °, ², ³, µ and '/'.
+func isUnitRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.Is(unicode.Sc, r) || strings.ContainsRune("%‰°²³µ/", r)
+}
+
+// currencyPrefixLen returns the length of a currency symbol at p.index that
+// is directly followed by a digit, or 0.
+func (p *JSONParser) currencyPrefixLen() int {
+	if p.index >= len(p.container) {
+		return 0
+	}
+	r, size := utf8.DecodeRuneInString(p.container[p.index:])
+	if !unicode.Is(unicode.Sc, r) {
+		return 0
+	}
+	if c, b := p.getByte(size); b && c >= '0' && c <= '9' {
+		return size
+	}
+	return 0
+}
+
+// parseNumberUnit consumes the unit after a number at p.index: unit runes,
+// optionally after spaces, followed only by whitespace before a ',', ']',
+// '}' or the end of the input. It returns ""
+// and consumes nothing if there is no such unit.
+func (p *JSONParser) parseNumberUnit() string {
+	i := p.index
+	for i < len(p.container) && p.container[i] == ' ' {
+		i++
+	}
+	j := i
+	for j < len(p.container) {
+		r, size := utf8.DecodeRuneInString(p.container[j:])
+		if !isUnitRune(r) {
+			break
+		}
+		j += size
+	}
+	unit := p.container[i:j]
+	if unit == "" {
+		return ""
+	}
+	// the unit must end the value, so "5 apples and 3 pears" has none
+	k := j
+	for k < len(p.container) && isASCIISpace(rune(p.container[k])) {
+		k++
+	}
+	if k < len(p.container) && strings.IndexByte(",]}", p.container[k]) == -1 {
+		return ""
+	}
+	switch strings.ToLower(unit) {
+	case "true", "false", "null":
+		return ""
+	}
+	p.index = j
+	return unit
+}
+
+// applyUnitPolicy turns the number n written with the given unit into the
+// value the unit policy asks for.
+func (p *JSONParser) applyUnitPolicy(n *Node, unit string, start int) *Node {
+	var rst *Node
+	switch p.opts.units {
+	case UnitsString:
+		rst = newScalarNode(strings.TrimSpace(p.container[start:p.index]), start, p.index)
+	case UnitsSplit:
+		rst = &Node{Kind: ObjectNode, Start: start, End: p.index, Members: []Member{
+			{Key: "value", Start: n.Start, End: n.End, Value: n},
+			{Key: "unit", Start: n.End, End: p.index, Value: newScalarNode(unit, n.End, p.index)},
+		}}
+	default:
+		n.Start, n.End = start, p.index
+		rst = n
+	}
+	for _, f := range n.Repairs {
+		rst.addRepair(f)
+	}
+	rst.addRepair(RepairNumberUnit)
+	return rst
+}
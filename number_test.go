@@ -0,0 +1,106 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_NumberLocale
+//
+//	Description:
+//	param t
+func Test_RepairJSON_NumberLocale(t *testing.T) {
+	tests := []struct {
+		in     string
+		locale NumberLocale
+		want   string
+	}{
+		{
+			in:     `{"price": 1,234.5, "count": 1,000,000, "plain": 1.5}`,
+			locale: LocaleEnglish,
+			want:   `{"price":1234.5,"count":1000000,"plain":1.5}`,
+		},
+		{
+			in:     `{"pi": 3,14, "total": 1.234.567, "amount": -1.000,25, "plain": 1.5}`,
+			locale: LocaleEuropean,
+			want:   `{"pi":3.14,"total":1234567,"amount":-1000.25,"plain":1.5}`,
+		},
+		{
+			in:     `{"bad": 12,34.5}`,
+			locale: LocaleEnglish,
+			want:   `{"bad":"12,34.5"}`,
+		},
+		{
+			in:     `[1,234, 5]`,
+			locale: LocaleEnglish,
+			want:   `[1,234,5]`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, WithNumberLocale(tt.locale))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+}
+
+// Test_RepairJSON_UnitPolicy
+//
+//	Description:
+//	param t
+func Test_RepairJSON_UnitPolicy(t *testing.T) {
+	in := `{"weight": 10kg, "price": $5, "rate": 50%, "cost": 5 €, "speed": 120 km/h, "temps": [-3°C, 20°C], "n": 1}`
+
+	tests := []struct {
+		policy UnitPolicy
+		want   string
+	}{
+		{
+			policy: UnitsNumber,
+			want:   `{"weight":10,"price":5,"rate":50,"cost":5,"speed":120,"temps":[-3,20],"n":1}`,
+		},
+		{
+			policy: UnitsString,
+			want:   `{"weight":"10kg","price":"$5","rate":"50%","cost":"5 €","speed":"120 km/h","temps":["-3°C","20°C"],"n":1}`,
+		},
+		{
+			policy: UnitsSplit,
+			want: `{"weight":{"value":10,"unit":"kg"},"price":{"value":5,"unit":"$"},"rate":{"value":50,"unit":"%"},` +
+				`"cost":{"value":5,"unit":"€"},"speed":{"value":120,"unit":"km/h"},` +
+				`"temps":[{"value":-3,"unit":"°C"},{"value":20,"unit":"°C"}],"n":1}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, report, err := RepairJSONWithReport(in, WithUnitPolicy(tt.policy))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSONWithReport() = %v, want %v", got, tt.want)
+			}
+			units := 0
+			for _, r := range report.Repairs {
+				if r.Flag == RepairNumberUnit {
+					units++
+				}
+			}
+			if units != 7 {
+				t.Errorf("number_unit repairs = %d, want 7", units)
+			}
+		})
+	}
+
+	// a number followed by more words has no unit
+	in = "{\"a\": 5 apples and 3 pears, \"b\": 2 kg\n}"
+	if got, _ := RepairJSON(in, WithUnitPolicy(UnitsString)); !jsonStringsEqual(got, `{"a":5,"b":"2 kg"}`) {
+		t.Errorf("RepairJSON() = %v, param in is %v", got, in)
+	}
+}
@@ -0,0 +1,338 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Option
+//
+//	Description: configures RepairJSON, MustRepairJSON, RepairJSONWithReport
+//	and ParseAST. The zero set of options keeps the default behavior.
+type Option func(*options)
+
+type options struct {
+	dropIncomplete bool
+	python         bool
+	javascript     bool
+	jsValues       map[string]any
+	invalidUTF8    InvalidUTF8Policy
+	normalization  map[rune]string
+	locale         NumberLocale
+	units          UnitPolicy
+	vocabulary     map[string]any
+	elision        ElisionPolicy
+	nested         NestedJSONMode
+	unwrapEncoded  bool
+	extract        []delimiter
+	fenceLanguages []string
+	fenceSelection FenceSelection
+	fenceSchema    *toolSchema
+}
+
+// newOptions applies opts on top of the defaults.
+func newOptions(opts []Option) *options {
+	o := &options{normalization: defaultNormalization, fenceLanguages: defaultFenceLanguages}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+	return o
+}
+
+// WithDropIncomplete
+//
+//	Description: drops the trailing element of a truncated document when it
+//	was cut mid-token or has no value yet, instead of keeping the half value.
+//	return Option
+func WithDropIncomplete() Option {
+	return func(o *options) {
+		o.dropIncomplete = true
+	}
+}
+
+// WithPythonLiterals
+//
+//	Description: accepts Python literal syntax as printed by repr(): True,
+//	False and None, tuples and sets (as arrays), u/r/b string prefixes and
+//	triple-quoted strings.
+//	return Option
+func WithPythonLiterals() Option {
+	return func(o *options) {
+		o.python = true
+	}
+}
+
+// WithJavaScriptLiterals
+//
+//	Description: accepts JavaScript object literal and JSON5 syntax: identifier
+//	keys (including '$' and '_'), single-quoted and backtick strings,
+//	hexadecimal numbers, numeric separators, a leading '+', and the values
+//	undefined, NaN, Infinity and -Infinity.
+//
+//	values maps those four names to the JSON value to emit, e.g.
+//	{"NaN": "NaN"}; names left out become null, as with JSON.stringify.
+//	param values
+//	return Option
+func WithJavaScriptLiterals(values map[string]any) Option {
+	return func(o *options) {
+		o.javascript = true
+		o.jsValues = values
+	}
+}
+
+// WithInvalidUTF8
+//
+//	Description: sets what happens to bytes that are not valid UTF-8. By
+//	default they reach the parser unchanged: the Windows-1252 (and Latin-1)
+//	fallback is opt-in with InvalidUTF8Windows1252. UTF-8 and UTF-16 byte
+//	order marks and UTF-16 input are handled regardless of the policy.
+//	param policy
+//	return Option
+func WithInvalidUTF8(policy InvalidUTF8Policy) Option {
+	return func(o *options) {
+		o.invalidUTF8 = policy
+	}
+}
+
+// WithNormalization
+//
+//	Description: replaces the table of characters normalized outside strings
+//	before parsing. Each character is replaced with its string, which may be
+//	empty to drop it. Start from DefaultNormalization to extend the default
+//	table; a nil table turns normalization off.
+//	param table
+//	return Option
+func WithNormalization(table map[rune]string) Option {
+	return func(o *options) {
+		o.normalization = table
+	}
+}
+
+// DefaultNormalization
+//
+//	Description: returns a copy of the default normalization table: Unicode
+//	spaces, zero-width characters, minus signs and full-width digits.
+//	return map[rune]string
+func DefaultNormalization() map[rune]string {
+	return maps.Clone(defaultNormalization)
+}
+
+// WithNumberLocale
+//
+//	Description: reads unquoted numbers written with the decimal and digit
+//	group separators of locale, e.g. LocaleEuropean for 1.234,5. Inside
+//	arrays ',' always separates elements.
+//	param locale
+//	return Option
+func WithNumberLocale(locale NumberLocale) Option {
+	return func(o *options) {
+		o.locale = locale
+	}
+}
+
+// WithUnitPolicy
+//
+//	Description: sets what happens to units, currency symbols and percent
+//	signs written next to unquoted numbers: 10kg, $5, 50%.
+//	param policy
+//	return Option
+func WithUnitPolicy(policy UnitPolicy) Option {
+	return func(o *options) {
+		o.units = policy
+	}
+}
+
+// defaultVocabulary maps the words models write for booleans and null.
+var defaultVocabulary = map[string]any{
+	"yes":       true,
+	"no":        false,
+	"on":        true,
+	"off":       false,
+	"nil":       nil,
+	"none":      nil,
+	"undefined": nil,
+	"n/a":       nil,
+	"是":         true,
+	"否":         false,
+	"はい":        true,
+	"いいえ":       false,
+}
+
+// WithLiteralVocabulary
+//
+//	Description: maps more unquoted words in value position to JSON values,
+//	compared case-insensitively, e.g. {"yes": true, "N/A": nil}. Quoted
+//	strings are never changed, and neither are words followed by more text,
+//	as in "no way". A nil vocabulary uses DefaultLiteralVocabulary. Of keys
+//	differing only in case, the first in sorted order wins.
+//	param vocabulary
+//	return Option
+func WithLiteralVocabulary(vocabulary map[string]any) Option {
+	if vocabulary == nil {
+		vocabulary = defaultVocabulary
+	}
+	keys := make([]string, 0, len(vocabulary))
+	for k := range vocabulary {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	folded := make(map[string]any, len(vocabulary))
+	for _, k := range keys {
+		if _, ok := folded[strings.ToLower(k)]; !ok {
+			folded[strings.ToLower(k)] = vocabulary[k]
+		}
+	}
+	return func(o *options) {
+		o.vocabulary = folded
+	}
+}
+
+// DefaultLiteralVocabulary
+//
+//	Description: returns a copy of the default vocabulary: yes/no, on/off,
+//	nil, none, undefined, N/A, 是/否 and はい/いいえ.
+//	return map[string]any
+func DefaultLiteralVocabulary() map[string]any {
+	return maps.Clone(defaultVocabulary)
+}
+
+// WithElisionPolicy
+//
+//	Description: sets what happens to elision markers written in place of
+//	elements: bare ..., …, [...] and {...}. Comments such as "// more items"
+//	are reported too. Quoted strings such as "..." are values and are never
+//	dropped, and input that is already valid JSON is never changed.
+//	param policy
+//	return Option
+func WithElisionPolicy(policy ElisionPolicy) Option {
+	return func(o *options) {
+		o.elision = policy
+	}
+}
+
+// WithNestedJSON
+//
+//	Description: repairs JSON held in string values, such as the arguments of
+//	a tool call, recursively with the same options. mode chooses whether the
+//	string is replaced with the value or keeps the repaired JSON as text.
+//	param mode
+//	return Option
+func WithNestedJSON(mode NestedJSONMode) Option {
+	return func(o *options) {
+		o.nested = mode
+	}
+}
+
+// WithUnwrapEncoded
+//
+//	Description: unwraps a document that was encoded as a JSON string one or
+//	more times ("{\"a\":1}") or had all of its quotes backslash-escaped
+//	({\"a\":1}), and repairs the inner document instead.
+//	return Option
+func WithUnwrapEncoded() Option {
+	return func(o *options) {
+		o.unwrapEncoded = true
+	}
+}
+
+// WithExtractTags
+//
+//	Description: extracts the document from the first element with one of
+//	the tag names, such as <json>...</json>, <answer> or <output>, before
+//	parsing. Tag names match case-insensitively, opening tags may carry
+//	attributes, and a missing closing tag extends to the end of the input.
+//	Extraction rules are tried in the order they were given.
+//	param names
+//	return Option
+func WithExtractTags(names ...string) Option {
+	return func(o *options) {
+		for _, name := range names {
+			o.extract = append(o.extract, delimiter{tag: name})
+		}
+	}
+}
+
+// WithExtractMarkers
+//
+//	Description: extracts the document between the first start marker and
+//	the end marker after it, such as BEGIN_JSON and END_JSON, before parsing.
+//	A missing end marker extends to the end of the input.
+//	param start
+//	param end
+//	return Option
+func WithExtractMarkers(start, end string) Option {
+	return func(o *options) {
+		o.extract = append(o.extract, delimiter{start: start, end: end})
+	}
+}
+
+// WithExtractPattern
+//
+//	Description: extracts the document matched by re before parsing: its
+//	first capturing group, or the whole match if it has none.
+//	param re
+//	return Option
+func WithExtractPattern(re *regexp.Regexp) Option {
+	return func(o *options) {
+		o.extract = append(o.extract, delimiter{pattern: re})
+	}
+}
+
+// WithFenceLanguages
+//
+//	Description: sets the info strings, such as json in ```json, that mark a
+//	code fence holding the document; they match case-insensitively. Start
+//	from DefaultFenceLanguages to extend the default list.
+//	param languages
+//	return Option
+func WithFenceLanguages(languages ...string) Option {
+	return func(o *options) {
+		o.fenceLanguages = languages
+	}
+}
+
+// DefaultFenceLanguages
+//
+//	Description: returns a copy of the default fence languages: json, jsonc
+//	and json5.
+//	return []string
+func DefaultFenceLanguages() []string {
+	return slices.Clone(defaultFenceLanguages)
+}
+
+// WithFenceSelection
+//
+//	Description: chooses the code fenced block holding the document when a
+//	response has several, such as an example followed by the answer. Only
+//	blocks tagged with one of the fence languages, or untagged, count. The
+//	last block is taken by default; FenceAll returns every block in an array.
+//	param policy
+//	return Option
+func WithFenceSelection(policy FenceSelection) Option {
+	return func(o *options) {
+		o.fenceSelection = policy
+	}
+}
+
+// WithFenceSchema
+//
+//	Description: selects the first code fenced block whose document fits
+//	schema, a JSON Schema with the keywords RepairToolCall understands, and
+//	the last block if none does or schema is invalid (FenceSchema).
+//	param schema
+//	return Option
+func WithFenceSchema(schema json.RawMessage) Option {
+	return func(o *options) {
+		s := &toolSchema{}
+		if err := json.Unmarshal(schema, s); err != nil {
+			o.fenceSelection, o.fenceSchema = FenceLast, nil
+			return
+		}
+		o.fenceSelection, o.fenceSchema = FenceSchema, s
+	}
+}
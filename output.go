@@ -0,0 +1,251 @@
+package jsonrepair
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// OutputFormat
+//
+//	Description: the text format MarshalNode writes
+type OutputFormat string
+
+const (
+	// FormatJSON is compact JSON, with object members in source order.
+	FormatJSON OutputFormat = "json"
+	// FormatJSON5 is indented JSON5: identifier keys unquoted, single-quoted
+	// strings, trailing commas and comments.
+	FormatJSON5 OutputFormat = "json5"
+	// FormatHJSON is indented HJSON: quoteless keys and strings where
+	// unambiguous, no commas, and comments.
+	FormatHJSON OutputFormat = "hjson"
+)
+
+// MarshalNode
+//
+//	Description: serializes the tree rooted at n in the given format. Unlike
+//	JSONMarshal it keeps object members in source order, and the JSON5 and
+//	HJSON formats keep the comments recorded on the tree.
+//	param n
+//	param format
+//	return []byte
+//	return error
+func MarshalNode(n *Node, format OutputFormat) ([]byte, error) {
+	w := &nodeWriter{format: format}
+	switch format {
+	case FormatJSON, FormatJSON5, FormatHJSON:
+	default:
+		return nil, fmt.Errorf("unknown output format %q", format)
+	}
+
+	if err := w.write(n, 0); err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
+
+type nodeWriter struct {
+	buf    bytes.Buffer
+	format OutputFormat
+}
+
+// pretty reports whether the format is indented and keeps comments.
+func (w *nodeWriter) pretty() bool {
+	return w.format != FormatJSON
+}
+
+func (w *nodeWriter) newline(depth int) {
+	w.buf.WriteByte('\n')
+	w.buf.WriteString(strings.Repeat("  ", depth))
+}
+
+func (w *nodeWriter) comments(comments []string, depth int) {
+	for _, c := range comments {
+		w.comment(c)
+		w.newline(depth)
+	}
+}
+
+// comment writes c, turning '#' comments into '//' ones for JSON5.
+func (w *nodeWriter) comment(c string) {
+	if w.format == FormatJSON5 && strings.HasPrefix(c, "#") {
+		c = "//" + c[1:]
+	}
+	w.buf.WriteString(c)
+}
+
+func (w *nodeWriter) write(n *Node, depth int) error {
+	if w.pretty() && depth == 0 {
+		w.comments(n.Comments, depth)
+	}
+
+	switch n.Kind {
+	case ArrayNode, ObjectNode:
+		return w.writeContainer(n, depth)
+	case StringNode:
+		w.writeString(n.str(), false)
+		return nil
+	}
+	if num, ok := n.Value.(json.Number); ok {
+		w.buf.WriteString(num.String())
+		return nil
+	}
+
+	bs, err := JSONMarshal(n.Value)
+	if err != nil {
+		return err
+	}
+	w.buf.Write(bytes.TrimSuffix(bs, []byte{'\n'}))
+	return nil
+}
+
+func (w *nodeWriter) writeContainer(n *Node, depth int) error {
+	open, end := byte('['), byte(']')
+	size := len(n.Children)
+	if n.Kind == ObjectNode {
+		open, end = '{', '}'
+		size = len(n.Members)
+	}
+
+	w.buf.WriteByte(open)
+	if size == 0 && (!w.pretty() || len(n.InnerComments) == 0) {
+		w.buf.WriteByte(end)
+		return nil
+	}
+
+	for i := 0; i < size; i++ {
+		if i > 0 && !w.pretty() {
+			w.buf.WriteByte(',')
+		}
+
+		var child *Node
+		if n.Kind == ObjectNode {
+			child = n.Members[i].Value
+		} else {
+			child = n.Children[i]
+		}
+
+		if w.pretty() {
+			w.newline(depth + 1)
+			w.comments(child.Comments, depth+1)
+		}
+		if n.Kind == ObjectNode {
+			w.writeKey(n.Members[i].Key)
+			w.buf.WriteByte(':')
+			if w.pretty() {
+				w.buf.WriteByte(' ')
+			}
+		}
+		if err := w.write(child, depth+1); err != nil {
+			return err
+		}
+		if w.format == FormatJSON5 {
+			w.buf.WriteByte(',')
+		}
+	}
+
+	if w.pretty() {
+		for _, c := range n.InnerComments {
+			w.newline(depth + 1)
+			w.comment(c)
+		}
+		w.newline(depth)
+	}
+	w.buf.WriteByte(end)
+	return nil
+}
+
+func (w *nodeWriter) writeKey(key string) {
+	switch w.format {
+	case FormatJSON5:
+		if isJSON5Identifier(key) {
+			w.buf.WriteString(key)
+			return
+		}
+	case FormatHJSON:
+		if key != "" && !strings.ContainsAny(key, ",:[]{}\"' \t\r\n") &&
+			!strings.HasPrefix(key, "#") && !strings.HasPrefix(key, "//") && !strings.HasPrefix(key, "/*") {
+			w.buf.WriteString(key)
+			return
+		}
+	}
+	w.writeString(key, true)
+}
+
+func (w *nodeWriter) writeString(s string, key bool) {
+	switch {
+	case w.format == FormatJSON5:
+		quote := byte('\'')
+		if strings.IndexByte(s, '\'') != -1 && strings.IndexByte(s, '"') == -1 {
+			quote = '"'
+		}
+		w.buf.WriteString(quoteJSON5String(s, quote))
+	case w.format == FormatHJSON && !key && isHJSONQuoteless(s):
+		w.buf.WriteString(s)
+	default:
+		w.buf.WriteString(quoteJSONString(s))
+	}
+}
+
+// quoteJSON5String quotes s with the given quote character, escaping
+// backslashes, the quote and control characters.
+func quoteJSON5String(s string, quote byte) string {
+	var sb strings.Builder
+	sb.WriteByte(quote)
+	for _, r := range s {
+		switch {
+		case r == rune(quote) || r == '\\':
+			sb.WriteByte('\\')
+			sb.WriteRune(r)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r < 0x20 || r == '\u2028' || r == '\u2029':
+			sb.WriteString(fmt.Sprintf(`\u%04x`, r))
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	sb.WriteByte(quote)
+	return sb.String()
+}
+
+// isJSON5Identifier reports whether key can be written without quotes in
+// JSON5, restricted to ASCII identifier names.
+func isJSON5Identifier(key string) bool {
+	if key == "" || !isIdentStart(key[0]) {
+		return false
+	}
+	for i := 1; i < len(key); i++ {
+		if !isIdentByte(key[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHJSONQuoteless reports whether s reads back as the same string when
+// written without quotes in HJSON: a single line without surrounding
+// whitespace that does not start like punctuation, a quoted string, a
+// comment, a number or a literal.
+func isHJSONQuoteless(s string) bool {
+	if s == "" || s != strings.TrimSpace(s) || strings.ContainsAny(s, "\r\n") {
+		return false
+	}
+	if strings.IndexByte("{}[],:\"'#", s[0]) != -1 || strings.HasPrefix(s, "//") || strings.HasPrefix(s, "/*") {
+		return false
+	}
+	if s[0] == '-' || (s[0] >= '0' && s[0] <= '9') {
+		return false
+	}
+	switch s {
+	case "true", "false", "null":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_MarshalNode
+//
+//	Description:
+//	param t
+func Test_MarshalNode(t *testing.T) {
+	tests := []struct {
+		in     string
+		format OutputFormat
+		want   string
+	}{
+		{
+			in:     `{"b": 1, "a": [true, null, "x"]}`,
+			format: FormatJSON,
+			want:   `{"b":1,"a":[true,null,"x"]}`,
+		},
+		{
+			in:     `{'name': 'John', "my-key": "it's", list: [1, 2,], empty: {}}`,
+			format: FormatJSON5,
+			want:   "{\n  name: 'John',\n  'my-key': \"it's\",\n  list: [\n    1,\n    2,\n  ],\n  empty: {},\n}",
+		},
+		{
+			in:     "// header\n{\n  # port to listen on\n  \"port\": 8080, /* trailing */\n}",
+			format: FormatJSON5,
+			want:   "// header\n{\n  // port to listen on\n  port: 8080,\n  /* trailing */\n}",
+		},
+		{
+			in:     `{"name": "John Smith", "age": "30", "tags": ["a b", "", "true", "x,y"]}`,
+			format: FormatHJSON,
+			want:   "{\n  name: John Smith\n  age: \"30\"\n  tags: [\n    a b\n    \"\"\n    \"true\"\n    x,y\n  ]\n}",
+		},
+		{
+			in:     "{\n  # database\n  \"db host\": \"localhost\"\n}",
+			format: FormatHJSON,
+			want:   "{\n  # database\n  \"db host\": localhost\n}",
+		},
+	}
+
+	for idx, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(idx), func(t *testing.T) {
+			root, err := ParseAST(tt.in)
+			if err != nil {
+				t.Fatalf("ParseAST() error = %v", err)
+			}
+			got, err := MarshalNode(root, tt.format)
+			if err != nil {
+				t.Fatalf("MarshalNode() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("MarshalNode() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := MarshalNode(newScalarNode(nil, 0, 0), "yaml"); err == nil {
+		t.Errorf("MarshalNode() expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,267 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// translatePython rewrites Python literal syntax, as printed by repr() of
+// dicts, lists, tuples and sets, into JSON-like text for the parser:
+//
+//   - True / False / None → true / false / null
+//   - tuples (1, 2) and sets {1, 2}, set() → arrays
+//   - u, r and b prefixed and triple-quoted strings → double-quoted JSON strings
+//
+// Anything else is copied verbatim, so mixed or broken input still reaches
+// the regular repair logic.
+func translatePython(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+
+	// closers of the open brackets, after translation
+	var stack []byte
+
+	i := 0
+	for i < len(s) {
+		c := s[i]
+
+		if start, quote, raw, ok := pythonStringStart(s, i); ok {
+			i = translateStringLiteral(&sb, s, start, quote, raw, len(quote) == 3)
+			continue
+		}
+
+		switch {
+		case isIdentStart(c) && (i == 0 || !isIdentByte(s[i-1])):
+			j := i
+			for j < len(s) && isIdentByte(s[j]) {
+				j++
+			}
+			switch word := s[i:j]; {
+			case word == "True":
+				sb.WriteString("true")
+			case word == "False":
+				sb.WriteString("false")
+			case word == "None":
+				sb.WriteString("null")
+			case (word == "set" || word == "frozenset") && strings.HasPrefix(s[j:], "()"):
+				sb.WriteString("[]")
+				j += 2
+			default:
+				sb.WriteString(word)
+			}
+			i = j
+			continue
+		case c == '(':
+			sb.WriteByte('[')
+			stack = append(stack, ']')
+		case c == '[':
+			sb.WriteByte('[')
+			stack = append(stack, ']')
+		case c == '{':
+			if isPythonSet(s, i) {
+				sb.WriteByte('[')
+				stack = append(stack, ']')
+			} else {
+				sb.WriteByte('{')
+				stack = append(stack, '}')
+			}
+		case c == ')' || c == ']' || c == '}':
+			if len(stack) > 0 {
+				sb.WriteByte(stack[len(stack)-1])
+				stack = stack[:len(stack)-1]
+			} else {
+				sb.WriteByte(c)
+			}
+		default:
+			sb.WriteByte(c)
+		}
+		i++
+	}
+
+	return sb.String()
+}
+
+// pythonStringStart reports whether a Python string literal, with optional
+// u/r/b/f prefix, starts at s[i]. It returns the index of the opening quote,
+// the quote itself (one or three characters) and whether the string is raw.
+func pythonStringStart(s string, i int) (start int, quote string, raw bool, ok bool) {
+	j := i
+	if i == 0 || !isIdentByte(s[i-1]) {
+		for j < len(s) && j-i < 2 && strings.IndexByte("rRuUbBfF", s[j]) != -1 {
+			raw = raw || s[j] == 'r' || s[j] == 'R'
+			j++
+		}
+	}
+	if j >= len(s) || (s[j] != '\'' && s[j] != '"') {
+		return 0, "", false, false
+	}
+
+	quote = s[j : j+1]
+	if strings.HasPrefix(s[j:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	return j, quote, raw, true
+}
+
+// translateStringLiteral writes the Python or JavaScript string literal whose
+// opening quote is at s[start] as a JSON string and returns the index after
+// it. Only multiline literals (triple-quoted, template) may contain raw line
+// breaks. An unterminated literal is written without a closing quote so the
+// parser still sees (and reports) an unclosed string.
+func translateStringLiteral(sb *strings.Builder, s string, start int, quote string, raw, multiline bool) int {
+	i := start + len(quote)
+	var content strings.Builder
+	closed := false
+
+	for i < len(s) {
+		if strings.HasPrefix(s[i:], quote) {
+			i += len(quote)
+			closed = true
+			break
+		}
+		if !multiline && (s[i] == '\n' || s[i] == '\r') {
+			break
+		}
+		if s[i] == '\\' && i+1 < len(s) {
+			if raw {
+				content.WriteString(s[i : i+2])
+				i += 2
+				continue
+			}
+			i = unescapeLiteral(&content, s, i)
+			continue
+		}
+		content.WriteByte(s[i])
+		i++
+	}
+
+	quoted := quoteJSONString(content.String())
+	if !closed {
+		quoted = quoted[:len(quoted)-1]
+	}
+	sb.WriteString(quoted)
+	return i
+}
+
+// unescapeLiteral decodes the Python or JavaScript escape sequence starting
+// with the backslash at s[i] and returns the index after it. Unknown escapes
+// are kept verbatim, as Python does.
+func unescapeLiteral(sb *strings.Builder, s string, i int) int {
+	c := s[i+1]
+	switch c {
+	case '\n':
+		return i + 2
+	case '\\', '\'', '"', '`', '/':
+		sb.WriteByte(c)
+		return i + 2
+	case 'n':
+		sb.WriteByte('\n')
+		return i + 2
+	case 't':
+		sb.WriteByte('\t')
+		return i + 2
+	case 'r':
+		sb.WriteByte('\r')
+		return i + 2
+	case 'b':
+		sb.WriteByte('\b')
+		return i + 2
+	case 'f':
+		sb.WriteByte('\f')
+		return i + 2
+	case 'v':
+		sb.WriteByte('\v')
+		return i + 2
+	case '0':
+		sb.WriteByte(0)
+		return i + 2
+	case 'x', 'u', 'U':
+		// JavaScript code point escape: \u{1F600}
+		if end := strings.IndexByte(s[i:], '}'); c == 'u' && strings.HasPrefix(s[i+2:], "{") && end > 0 {
+			if r, err := strconv.ParseUint(s[i+3:i+end], 16, 32); err == nil && utf8.ValidRune(rune(r)) {
+				sb.WriteRune(rune(r))
+				return i + end + 1
+			}
+		}
+		size := map[byte]int{'x': 2, 'u': 4, 'U': 8}[c]
+		if i+2+size <= len(s) {
+			r, err := strconv.ParseUint(s[i+2:i+2+size], 16, 32)
+			switch {
+			case err != nil:
+			case c == 'u' && utf16.IsSurrogate(rune(r)):
+				// join a surrogate pair; a lone surrogate becomes U+FFFD
+				end := i + 2 + size
+				if strings.HasPrefix(s[end:], "\\u") && end+6 <= len(s) {
+					if lo, err := strconv.ParseUint(s[end+2:end+6], 16, 32); err == nil {
+						if pair := utf16.DecodeRune(rune(r), rune(lo)); pair != utf8.RuneError {
+							sb.WriteRune(pair)
+							return end + 6
+						}
+					}
+				}
+				sb.WriteRune(utf8.RuneError)
+				return end
+			case utf8.ValidRune(rune(r)):
+				sb.WriteRune(rune(r))
+				return i + 2 + size
+			}
+		}
+	}
+	sb.WriteString(s[i : i+2])
+	return i + 2
+}
+
+// isPythonSet reports whether the brace at s[i] opens a set rather than a
+// dict: a non-empty literal without a ':' at its own nesting level.
+func isPythonSet(s string, i int) bool {
+	depth := 0
+	empty := true
+	for j := i + 1; j < len(s); j++ {
+		if start, quote, _, ok := pythonStringStart(s, j); ok {
+			// skip the literal, honoring escaped quotes
+			k := start + len(quote)
+			for k < len(s) && !strings.HasPrefix(s[k:], quote) {
+				if s[k] == '\\' {
+					k++
+				}
+				k++
+			}
+			if k >= len(s) {
+				return false
+			}
+			j = k + len(quote) - 1
+			empty = false
+			continue
+		}
+		switch c := s[j]; {
+		case c == '{' || c == '[' || c == '(':
+			depth++
+		case c == '}' || c == ']' || c == ')':
+			if depth == 0 {
+				return !empty
+			}
+			depth--
+		case c == ':' && depth == 0:
+			return false
+		case c != ' ' && c != '\t' && c != '\n' && c != '\r':
+			empty = false
+		}
+	}
+	return false
+}
+
+// quoteJSONString returns s as a double-quoted JSON string.
+func quoteJSONString(s string) string {
+	bs, _ := JSONMarshal(s)
+	return strings.TrimSuffix(string(bs), "\n")
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentByte(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
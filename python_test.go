@@ -0,0 +1,78 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_Python
+//
+//	Description:
+//	param t
+func Test_RepairJSON_Python(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   `{'name': 'John', 'active': True, 'admin': False, 'manager': None}`,
+			want: `{"name":"John","active":true,"admin":false,"manager":null}`,
+		},
+		{
+			in:   `{'point': (1, 2), 'empty': (), 'single': (1,)}`,
+			want: `{"point":[1,2],"empty":[],"single":[1]}`,
+		},
+		{
+			in:   `{'tags': {'a', 'b'}, 'none': set(), 'dict': {}}`,
+			want: `{"tags":["a","b"],"none":[],"dict":{}}`,
+		},
+		{
+			in:   `{u'key': u'value', 'path': r'C:\new\table', 'data': b'\x41\x42'}`,
+			want: `{"key":"value","path":"C:\\new\\table","data":"AB"}`,
+		},
+		{
+			in:   `{'it': 'it\'s', "quote": "say \"hi\""}`,
+			want: `{"it":"it's","quote":"say \"hi\""}`,
+		},
+		{
+			in:   "{'code': '''def f():\n    return \"x\" # done\n''', 'doc': \"\"\"a 'b' c\"\"\"}",
+			want: `{"code":"def f():\n    return \"x\" # done\n","doc":"a 'b' c"}`,
+		},
+		{
+			in:   `[(1, 'a', {'k': None}), (2, 'b', {'k': True})]`,
+			want: `[[1,"a",{"k":null}],[2,"b",{"k":true}]]`,
+		},
+		{
+			in:   `{'Truest': 'None of them', 'x': NoneType}`,
+			want: `{"Truest":"None of them","x":"NoneType"}`,
+		},
+		{
+			in:   `{'values': (1, 2, 3`,
+			want: `{"values":[1,2,3]}`,
+		},
+		{
+			in:   `{'note': 'unterminated`,
+			want: `{"note":"unterminated"}`,
+		},
+		{
+			in:   `{"a": "a\/b", "e": "\ud83d\ude00"}`,
+			want: `{"a":"a/b","e":"😀"}`,
+		},
+		{
+			in:   `{'a': 'a\/b', 'e': '\ud83d\ude00', 'lone': '\ud83d!', 'x': True`,
+			want: `{"a":"a/b","e":"😀","lone":"\ufffd!","x":true}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, WithPythonLiterals())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+}
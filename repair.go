@@ -0,0 +1,166 @@
+package jsonrepair
+
+// RepairFlag
+//
+//	Description: identifies one kind of repair applied while parsing
+type RepairFlag string
+
+const (
+	// RepairEncoding: the input was transcoded to UTF-8 or had a byte order mark removed.
+	RepairEncoding RepairFlag = "encoding"
+	// RepairInvalidUTF8: invalid UTF-8 bytes were replaced with U+FFFD (InvalidUTF8Replace).
+	RepairInvalidUTF8 RepairFlag = "invalid_utf8"
+	// RepairUnwrappedEncoding: the document was unwrapped from string encoding (WithUnwrapEncoded).
+	RepairUnwrappedEncoding RepairFlag = "unwrapped_encoding"
+	// RepairCodeFence: the document was wrapped in a ``` code fence.
+	RepairCodeFence RepairFlag = "code_fence"
+	// RepairExtracted: the document was extracted from tags, markers or a pattern (WithExtractTags).
+	RepairExtracted RepairFlag = "extracted"
+	// RepairComments: comments were stripped from the document.
+	RepairComments RepairFlag = "comments"
+	// RepairNormalizedCharacters: invisible or confusable characters outside strings were normalized.
+	RepairNormalizedCharacters RepairFlag = "normalized_characters"
+	// RepairFullWidthPunctuation: full-width or CJK structural characters were folded to ASCII.
+	RepairFullWidthPunctuation RepairFlag = "full_width_punctuation"
+	// RepairSkippedText: unexpected text before the value was skipped.
+	RepairSkippedText RepairFlag = "skipped_text"
+	// RepairMultipleRoots: several top-level values were collected into an array.
+	RepairMultipleRoots RepairFlag = "multiple_roots"
+	// RepairPythonLiterals: Python literal syntax was translated (WithPythonLiterals).
+	RepairPythonLiterals RepairFlag = "python_literals"
+	// RepairJavaScriptLiterals: JavaScript or JSON5 syntax was translated (WithJavaScriptLiterals).
+	RepairJavaScriptLiterals RepairFlag = "javascript_literals"
+
+	// RepairMissingQuotes: an unquoted key or string value was quoted.
+	RepairMissingQuotes RepairFlag = "missing_quotes"
+	// RepairSingleQuotes: a single-quoted string was converted.
+	RepairSingleQuotes RepairFlag = "single_quotes"
+	// RepairSmartQuotes: typographic or full-width quotes delimited a string.
+	RepairSmartQuotes RepairFlag = "smart_quotes"
+	// RepairDoubledQuotes: a string was wrapped in doubled quotes (""value"").
+	RepairDoubledQuotes RepairFlag = "doubled_quotes"
+	// RepairEmbeddedQuote: an unescaped quote was kept as part of the string (Issue #18).
+	RepairEmbeddedQuote RepairFlag = "embedded_quote"
+	// RepairUnclosedString: the input ended inside a string.
+	RepairUnclosedString RepairFlag = "unclosed_string"
+	// RepairCodeFenceValue: a ```json block inside a string was parsed as the value.
+	RepairCodeFenceValue RepairFlag = "code_fence_value"
+	// RepairNestedJSON: JSON held in this string was repaired and unwrapped or re-encoded (WithNestedJSON).
+	RepairNestedJSON RepairFlag = "nested_json"
+	// RepairHexEscape: a \xNN escape was converted to the code point U+00NN.
+	RepairHexEscape RepairFlag = "hex_escape"
+	// RepairInvalidEscape: an unknown or truncated escape was kept literally or had its backslash dropped.
+	RepairInvalidEscape RepairFlag = "invalid_escape"
+	// RepairLoneSurrogate: an unpaired UTF-16 surrogate escape was replaced with U+FFFD.
+	RepairLoneSurrogate RepairFlag = "lone_surrogate"
+	// RepairRawNewline: a raw line break inside a string was escaped.
+	RepairRawNewline RepairFlag = "raw_newline"
+	// RepairControlCharacter: a raw control character inside a string was escaped.
+	RepairControlCharacter RepairFlag = "control_character"
+
+	// RepairLiteralCase: true, false or null was written with the wrong case.
+	RepairLiteralCase RepairFlag = "literal_case"
+	// RepairLiteralVocabulary: an unquoted word was mapped to a value (WithLiteralVocabulary).
+	RepairLiteralVocabulary RepairFlag = "literal_vocabulary"
+	// RepairNumberAsString: a malformed number was kept as a string.
+	RepairNumberAsString RepairFlag = "number_as_string"
+	// RepairNumberTrimmed: a dangling sign, exponent or separator was cut from a number.
+	RepairNumberTrimmed RepairFlag = "number_trimmed"
+	// RepairNumberLocale: a number with locale decimal or group separators was converted (WithNumberLocale).
+	RepairNumberLocale RepairFlag = "number_locale"
+	// RepairNumberUnit: a unit next to a number was handled by the unit policy (WithUnitPolicy).
+	RepairNumberUnit RepairFlag = "number_unit"
+
+	// RepairUnclosedArray: the input ended inside an array, which was closed by the repairer.
+	RepairUnclosedArray RepairFlag = "unclosed_array"
+	// RepairUnclosedObject: the input ended inside an object, which was closed by the repairer.
+	RepairUnclosedObject RepairFlag = "unclosed_object"
+	// RepairMismatchedBracket: an array was closed with '}' (PR #21).
+	RepairMismatchedBracket RepairFlag = "mismatched_bracket"
+	// RepairTrailingComma: a trailing comma before a closing bracket was dropped.
+	RepairTrailingComma RepairFlag = "trailing_comma"
+	// RepairMissingKey: an object value without a key got a placeholder key.
+	RepairMissingKey RepairFlag = "missing_key"
+	// RepairDuplicateKey: this value replaced an earlier one with the same key.
+	RepairDuplicateKey RepairFlag = "duplicate_key"
+	// RepairSplitObject: an object was split at a repeated key into several values.
+	RepairSplitObject RepairFlag = "split_object"
+	// RepairEllipsis: elements were elided with a marker such as "...", "etc" or a "// more items"
+	// comment. On a container the marker was dropped; on a string it was kept (ElisionKeep).
+	RepairEllipsis RepairFlag = "ellipsis"
+
+	// RepairTruncatedToken: the input ended inside this string or number, so it is probably incomplete.
+	RepairTruncatedToken RepairFlag = "truncated_token"
+	// RepairDroppedIncomplete: the incomplete last element of this container was dropped (WithDropIncomplete).
+	RepairDroppedIncomplete RepairFlag = "dropped_incomplete"
+
+	// RepairSchemaCoercion: the value was converted to the type or enum value its schema expects, or the
+	// object key was corrected to a near-miss property name (RepairToolCall).
+	RepairSchemaCoercion RepairFlag = "schema_coercion"
+)
+
+// repairConfidence is how likely each repair is to restore what the author
+// meant. Mechanical fixes are near-certain; guesses about where a string ends
+// or which key a value belongs to are not.
+var repairConfidence = map[RepairFlag]float64{
+	RepairEncoding:             0.95,
+	RepairInvalidUTF8:          0.8,
+	RepairUnwrappedEncoding:    0.95,
+	RepairCodeFence:            0.99,
+	RepairExtracted:            0.99,
+	RepairComments:             0.95,
+	RepairNormalizedCharacters: 0.95,
+	RepairFullWidthPunctuation: 0.95,
+	RepairSkippedText:          0.8,
+	RepairMultipleRoots:        0.8,
+	RepairPythonLiterals:       0.95,
+	RepairJavaScriptLiterals:   0.95,
+
+	RepairMissingQuotes:    0.85,
+	RepairSingleQuotes:     0.98,
+	RepairSmartQuotes:      0.95,
+	RepairDoubledQuotes:    0.9,
+	RepairEmbeddedQuote:    0.5,
+	RepairUnclosedString:   0.7,
+	RepairCodeFenceValue:   0.9,
+	RepairNestedJSON:       0.9,
+	RepairHexEscape:        0.95,
+	RepairInvalidEscape:    0.8,
+	RepairLoneSurrogate:    0.9,
+	RepairRawNewline:       0.99,
+	RepairControlCharacter: 0.99,
+
+	RepairLiteralCase:       0.98,
+	RepairLiteralVocabulary: 0.85,
+	RepairNumberAsString:    0.7,
+	RepairNumberTrimmed:     0.8,
+	RepairNumberLocale:      0.85,
+	RepairNumberUnit:        0.8,
+
+	RepairUnclosedArray:     0.85,
+	RepairUnclosedObject:    0.85,
+	RepairMismatchedBracket: 0.75,
+	RepairTrailingComma:     0.99,
+	RepairMissingKey:        0.5,
+	RepairDuplicateKey:      0.8,
+	RepairSplitObject:       0.6,
+	RepairEllipsis:          0.9,
+
+	RepairTruncatedToken:    0.6,
+	RepairDroppedIncomplete: 0.9,
+
+	RepairSchemaCoercion: 0.9,
+}
+
+// Confidence
+//
+//	Description: how likely the repair is to be correct, from 0 to 1.
+//	Unknown flags are treated as guesses (0.5).
+//	receiver f
+//	return float64
+func (f RepairFlag) Confidence() float64 {
+	if c, ok := repairConfidence[f]; ok {
+		return c
+	}
+	return 0.5
+}
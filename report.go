@@ -0,0 +1,152 @@
+package jsonrepair
+
+import (
+	"fmt"
+	"math"
+	"runtime/debug"
+)
+
+// RepairEntry
+//
+//	Description: a single repair of a Report. Path is the JSON Pointer of the
+//	repaired node; Key is set when the repair applied to the object key at Path.
+type RepairEntry struct {
+	Flag       RepairFlag
+	Path       string
+	Key        bool
+	Start      int
+	End        int
+	Confidence float64
+}
+
+// Report
+//
+//	Description: describes the repairs applied to a document. Confidence is
+//	1 for input that needed no repair and drops with every guess the repairer
+//	had to make. A mechanical repair, with a confidence of at least 0.95,
+//	counts once however often it was applied. Each further occurrence of a
+//	guess counts with a diminishing weight: the n-th time a guess of
+//	confidence c was made multiplies Confidence by c^(1/2^(n-1)), so many
+//	guesses of one kind score lower than one but never below c².
+//
+//	Truncated is set when the input ended inside a string or container, as
+//	happens when a model hits its token limit. AutoClosed lists the JSON
+//	Pointers of the containers the repairer closed, and TruncatedToken tells
+//	whether the last string or number was cut mid-token.
+//
+//	EncodedLayers counts the layers of string encoding or quote escaping
+//	around the document once its encoding is decoded and it is extracted from
+//	code fences or tags, whether or not WithUnwrapEncoded removed them.
+type Report struct {
+	Repairs    []RepairEntry
+	Confidence float64
+
+	Truncated      bool
+	AutoClosed     []string
+	TruncatedToken bool
+
+	EncodedLayers int
+}
+
+// Repaired
+//
+//	Description: reports whether any repair was applied
+//	receiver r
+//	return bool
+func (r *Report) Repaired() bool {
+	return len(r.Repairs) > 0
+}
+
+// mechanicalConfidence is the confidence from which a repair is a
+// mechanical fix rather than a guess, see Report.
+const mechanicalConfidence = 0.95
+
+// NewReport
+//
+//	Description: collects the repairs recorded in the tree rooted at root.
+//	A flag recorded on both the key and the value of a member is reported
+//	once, for the value, with Start at the key.
+//	param root
+//	return *Report
+func NewReport(root *Node) *Report {
+	r := &Report{Confidence: 1}
+	seen := map[RepairFlag]int{}
+	add := func(flag RepairFlag, path string, key bool, start, end int) {
+		c := flag.Confidence()
+		r.Repairs = append(r.Repairs, RepairEntry{Flag: flag, Path: path, Key: key, Start: start, End: end, Confidence: c})
+		switch n := seen[flag]; {
+		case n == 0:
+			r.Confidence *= c
+		case c < mechanicalConfidence:
+			r.Confidence *= math.Pow(c, math.Pow(0.5, float64(n)))
+		}
+		seen[flag]++
+
+		switch flag {
+		case RepairUnclosedArray, RepairUnclosedObject:
+			r.AutoClosed = append(r.AutoClosed, path)
+			r.Truncated = true
+		case RepairUnclosedString, RepairDroppedIncomplete:
+			r.Truncated = true
+		case RepairTruncatedToken:
+			r.Truncated = true
+			r.TruncatedToken = true
+		}
+	}
+
+	// a repair of both the key and the value of a member, such as the
+	// single quotes of {'a': 'x'}, is one entry spanning the member
+	type valueRepair struct {
+		value *Node
+		flag  RepairFlag
+	}
+	memberStart := map[valueRepair]int{}
+
+	Walk(root, func(path string, n *Node) bool {
+		for _, f := range n.Repairs {
+			start, ok := memberStart[valueRepair{n, f}]
+			if !ok {
+				start = n.Start
+			}
+			add(f, path, false, start, n.End)
+		}
+		for _, m := range n.Members {
+			for _, f := range m.Repairs {
+				if m.Value != nil && m.Value.HasRepair(f) {
+					memberStart[valueRepair{m.Value, f}] = m.Start
+					continue
+				}
+				add(f, path+"/"+escapePointerToken(m.Key), true, m.Start, m.End)
+			}
+		}
+		return true
+	})
+	return r
+}
+
+// RepairJSONWithReport
+//
+//	Description: like RepairJSON, but also returns what was repaired and how
+//	confident the repairer is in the result
+//	param src
+//	param opts
+//	return dst
+//	return report
+//	return err
+func RepairJSONWithReport(src string, opts ...Option) (dst string, report *Report, err error) {
+	defer func() {
+		if errR := recover(); errR != nil {
+			stack := string(debug.Stack())
+			err = fmt.Errorf("repair json panic: %s", stack)
+			return
+		}
+	}()
+
+	dst, root, layers, err := repairDocument(src, true, newOptions(opts))
+	if err != nil {
+		return "", nil, err
+	}
+	report = NewReport(root)
+	report.EncodedLayers = layers
+	return dst, report, nil
+}
@@ -0,0 +1,210 @@
+package jsonrepair
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test_RepairJSONWithReport
+//
+//	Description:
+//	param t
+func Test_RepairJSONWithReport(t *testing.T) {
+	tests := []struct {
+		in         string
+		want       string
+		wantFlags  []RepairFlag
+		wantPaths  []string
+		confidence float64
+	}{
+		{
+			in:         `{"a": 1, "b": [1, 2]}`,
+			want:       `{"a":1,"b":[1,2]}`,
+			confidence: 1,
+		},
+		{
+			in:         `[1, 2, 3,]`,
+			want:       `[1,2,3]`,
+			wantFlags:  []RepairFlag{RepairTrailingComma},
+			wantPaths:  []string{""},
+			confidence: 0.99,
+		},
+		{
+			in:         `{'a': 1, b: 'x'}`,
+			want:       `{"a":1,"b":"x"}`,
+			wantFlags:  []RepairFlag{RepairSingleQuotes, RepairMissingQuotes, RepairSingleQuotes},
+			wantPaths:  []string{"/a", "/b", "/b"},
+			confidence: 0.98 * 0.85,
+		},
+		{
+			in:         `{"name": "John is "good",hah", "age": 30}`,
+			want:       `{"name":"John is \"good\",hah","age":30}`,
+			wantFlags:  []RepairFlag{RepairEmbeddedQuote},
+			wantPaths:  []string{"/name"},
+			confidence: 0.5,
+		},
+		{
+			in:         `{'a': 'x  ', msg: hello: world}`,
+			want:       `{"a":"x  ","msg":"hello: world"}`,
+			wantFlags:  []RepairFlag{RepairSingleQuotes, RepairMissingQuotes},
+			wantPaths:  []string{"/a", "/msg"},
+			confidence: 0.98 * 0.85,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, report, err := RepairJSONWithReport(tt.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSONWithReport() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+
+			var flags []RepairFlag
+			var paths []string
+			for _, r := range report.Repairs {
+				flags = append(flags, r.Flag)
+				paths = append(paths, r.Path)
+			}
+			if !reflect.DeepEqual(flags, tt.wantFlags) || !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("Repairs = %v %v, want %v %v", flags, paths, tt.wantFlags, tt.wantPaths)
+			}
+			if report.Repaired() != (len(tt.wantFlags) > 0) {
+				t.Errorf("Repaired() = %v", report.Repaired())
+			}
+			for _, r := range report.Repairs {
+				if r.Start >= r.End {
+					t.Errorf("repair %v spans %d-%d", r.Flag, r.Start, r.End)
+				}
+			}
+			if math.Abs(report.Confidence-tt.confidence) > 1e-9 {
+				t.Errorf("Confidence = %v, want %v", report.Confidence, tt.confidence)
+			}
+		})
+	}
+
+	// the same mechanical repair applied many times is no less certain
+	in := "[" + strings.Repeat("'x', ", 99) + "'x']"
+	_, report, err := RepairJSONWithReport(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Repairs) != 100 || math.Abs(report.Confidence-0.98) > 1e-9 {
+		t.Errorf("%d repairs with Confidence = %v, want 100 with %v", len(report.Repairs), report.Confidence, 0.98)
+	}
+
+	// repeated guesses score lower than one, but never below its square
+	_, one, err := RepairJSONWithReport(`{"a": "x "y" z"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, three, err := RepairJSONWithReport(`{"a": "x "y" z", "b": "x "y" z", "c": "x "y" z"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 0.5 * math.Sqrt(0.5) * math.Pow(0.5, 0.25); math.Abs(three.Confidence-want) > 1e-9 ||
+		one.Confidence != 0.5 || three.Confidence < 0.25 {
+		t.Errorf("Confidence = %v for one guess and %v for three, want 0.5 and %v", one.Confidence, three.Confidence, want)
+	}
+}
+
+// Test_RepairJSONWithReport_Truncated
+//
+//	Description:
+//	param t
+func Test_RepairJSONWithReport_Truncated(t *testing.T) {
+	tests := []struct {
+		in             string
+		opts           []Option
+		want           string
+		truncated      bool
+		autoClosed     []string
+		truncatedToken bool
+	}{
+		{
+			in:   `{"a": [1, 2], "b": "done"}`,
+			want: `{"a":[1,2],"b":"done"}`,
+		},
+		{
+			in:   `{"a": [1, 2]} trailing text`,
+			want: `{"a":[1,2]}`,
+		},
+		{
+			in:             `{"items": [{"id": 1}, {"id": 2, "name": "Ann`,
+			want:           `{"items":[{"id":1},{"id":2,"name":"Ann"}]}`,
+			truncated:      true,
+			autoClosed:     []string{"", "/items", "/items/1"},
+			truncatedToken: true,
+		},
+		{
+			in:             `{"items": [{"id": 1}, {"id": 2, "name": "Ann`,
+			opts:           []Option{WithDropIncomplete()},
+			want:           `{"items":[{"id":1},{"id":2}]}`,
+			truncated:      true,
+			autoClosed:     []string{"", "/items", "/items/1"},
+			truncatedToken: true,
+		},
+		{
+			in:             `{"values": [10, 20, 3`,
+			opts:           []Option{WithDropIncomplete()},
+			want:           `{"values":[10,20]}`,
+			truncated:      true,
+			autoClosed:     []string{"", "/values"},
+			truncatedToken: true,
+		},
+		{
+			in:         `{"values": [10, 20, "x"`,
+			opts:       []Option{WithDropIncomplete()},
+			want:       `{"values":[10,20,"x"]}`,
+			truncated:  true,
+			autoClosed: []string{"", "/values"},
+		},
+		{
+			in:         `{"a": 1, "b":`,
+			want:       `{"a":1,"b":""}`,
+			truncated:  true,
+			autoClosed: []string{""},
+		},
+		{
+			in:         `{"a": 1, "b":`,
+			opts:       []Option{WithDropIncomplete()},
+			want:       `{"a":1}`,
+			truncated:  true,
+			autoClosed: []string{""},
+		},
+		{
+			in:             `{"a": 1, "lo`,
+			opts:           []Option{WithDropIncomplete()},
+			want:           `{"a":1}`,
+			truncated:      true,
+			autoClosed:     []string{""},
+			truncatedToken: true,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, report, err := RepairJSONWithReport(tt.in, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSONWithReport() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+			if got := MustRepairJSON(tt.in, tt.opts...); !jsonStringsEqual(got, tt.want) {
+				t.Errorf("MustRepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+			if report.Truncated != tt.truncated || report.TruncatedToken != tt.truncatedToken ||
+				!reflect.DeepEqual(report.AutoClosed, tt.autoClosed) {
+				t.Errorf("Truncated, AutoClosed, TruncatedToken = %v %q %v, want %v %q %v",
+					report.Truncated, report.AutoClosed, report.TruncatedToken,
+					tt.truncated, tt.autoClosed, tt.truncatedToken)
+			}
+		})
+	}
+}
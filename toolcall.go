@@ -0,0 +1,594 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrUnknownTool is wrapped by the ToolCallError of a call naming no
+	// known tool.
+	ErrUnknownTool = errors.New("jsonrepair: unknown tool")
+	// ErrInvalidArguments is wrapped by the ToolCallError of a call whose
+	// arguments do not fit the tool's schema even after repair.
+	ErrInvalidArguments = errors.New("jsonrepair: invalid tool arguments")
+)
+
+// Tool
+//
+//	Description: a tool or function the model can call. Parameters is the
+//	JSON Schema of its arguments object; an empty schema accepts any object.
+type Tool struct {
+	Name       string
+	Parameters json.RawMessage
+}
+
+// ToolCall
+//
+//	Description: a repaired tool call. Name is the name of the matched tool
+//	and CalledName the name the model used, which differs when it was a
+//	near miss. Arguments is the repaired arguments object, coerced to the
+//	tool's schema, and Report lists the repairs applied to it.
+type ToolCall struct {
+	Name       string
+	CalledName string
+	Arguments  json.RawMessage
+	Report     *Report
+}
+
+// Decode
+//
+//	Description: unmarshals the arguments into v
+//	receiver c
+//	param v
+//	return error
+func (c *ToolCall) Decode(v any) error {
+	return json.Unmarshal(c.Arguments, v)
+}
+
+// ToolCallProblem
+//
+//	Description: a single problem of a ToolCallError. Path is the JSON
+//	Pointer of the offending argument, empty for the call itself.
+type ToolCallProblem struct {
+	Path    string
+	Message string
+}
+
+// ToolCallError
+//
+//	Description: a tool call that could not be repaired. Err is
+//	ErrUnknownTool or ErrInvalidArguments. Its message lists every problem
+//	and, for an unknown tool, the available tools, so it can be sent back to
+//	the model as is.
+type ToolCallError struct {
+	Err       error
+	Tool      string
+	Problems  []ToolCallProblem
+	Available []string
+}
+
+// Error
+//
+//	Description:
+//	receiver e
+//	return string
+func (e *ToolCallError) Error() string {
+	var sb strings.Builder
+	if errors.Is(e.Err, ErrUnknownTool) {
+		fmt.Fprintf(&sb, "unknown tool %q", e.Tool)
+		if len(e.Available) > 0 {
+			sb.WriteString("; available tools: " + strings.Join(e.Available, ", "))
+		}
+	} else {
+		fmt.Fprintf(&sb, "invalid arguments for tool %q", e.Tool)
+	}
+	for i, p := range e.Problems {
+		if i == 0 {
+			sb.WriteString(": ")
+		} else {
+			sb.WriteString("; ")
+		}
+		if p.Path != "" {
+			sb.WriteString(p.Path + ": ")
+		}
+		sb.WriteString(p.Message)
+	}
+	return sb.String()
+}
+
+// Unwrap
+//
+//	Description:
+//	receiver e
+//	return error
+func (e *ToolCallError) Unwrap() error {
+	return e.Err
+}
+
+// RepairToolCall
+//
+//	Description: repairs a tool call made by a model against the tools it
+//	was offered. payload is the call as the model emitted it: an object with
+//	"name" and "arguments" (OpenAI, where arguments is usually a string of
+//	JSON), "name" and "input" (Anthropic), or the same nested under
+//	"function". A near-miss tool name is corrected to the closest tool, the
+//	arguments are repaired, and their values are coerced to the types and
+//	enum values the tool's schema expects: "3" becomes 3 for an integer, a
+//	lone value becomes a one-element array, and a near-miss property name is
+//	corrected.
+//
+//	The supported schema keywords are type, properties, required,
+//	additionalProperties (as a boolean), items and enum. When the call still
+//	does not fit, the error is a *ToolCallError, returned together with the
+//	repaired call.
+//	param tools
+//	param payload
+//	param opts
+//	return *ToolCall
+//	return error
+func RepairToolCall(tools []Tool, payload string, opts ...Option) (*ToolCall, error) {
+	root, err := ParseAST(payload, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if fn := root.memberValue("function"); fn != nil && fn.Kind == ObjectNode {
+		root = fn
+	}
+
+	calledName := root.memberValue("name").str()
+	tool, ok := matchTool(tools, calledName)
+	if !ok {
+		available := make([]string, 0, len(tools))
+		for _, t := range tools {
+			available = append(available, t.Name)
+		}
+		return nil, &ToolCallError{Err: ErrUnknownTool, Tool: calledName, Available: available}
+	}
+
+	var schema *toolSchema
+	if len(tool.Parameters) > 0 {
+		schema = &toolSchema{}
+		if err := json.Unmarshal(tool.Parameters, schema); err != nil {
+			return nil, fmt.Errorf("jsonrepair: invalid parameters schema of tool %q: %w", tool.Name, err)
+		}
+	}
+
+	args := root.memberValue("arguments")
+	if args == nil {
+		args = root.memberValue("input")
+	}
+	if args, err = toolArguments(args, opts); err != nil {
+		return nil, err
+	}
+
+	c := &schemaCoercer{opts: opts}
+	if args.Kind != ObjectNode {
+		c.problem("", "arguments must be an object, got "+args.Kind.String())
+	} else {
+		args = c.coerce("", schema, args)
+	}
+
+	dst, err := MarshalNode(args, FormatJSON)
+	if err != nil {
+		return nil, err
+	}
+	call := &ToolCall{Name: tool.Name, CalledName: calledName, Arguments: dst, Report: NewReport(args)}
+	if len(c.problems) > 0 {
+		return call, &ToolCallError{Err: ErrInvalidArguments, Tool: tool.Name, Problems: c.problems}
+	}
+	return call, nil
+}
+
+// memberValue returns the value of the member key of an object node, or nil.
+func (n *Node) memberValue(key string) *Node {
+	if n == nil || n.Kind != ObjectNode {
+		return nil
+	}
+	if i := n.member(key); i >= 0 {
+		return n.Members[i].Value
+	}
+	return nil
+}
+
+// toolArguments returns the arguments tree of a call: a missing or empty
+// arguments value is an empty object, and a string is repaired as JSON.
+func toolArguments(args *Node, opts []Option) (*Node, error) {
+	if args == nil || args.Kind == NullNode {
+		return &Node{Kind: ObjectNode}, nil
+	}
+	if args.Kind != StringNode {
+		return args, nil
+	}
+	s := strings.TrimSpace(args.str())
+	if s == "" {
+		return &Node{Kind: ObjectNode}, nil
+	}
+	return ParseAST(s, opts...)
+}
+
+// matchTool finds the tool called name: by exact name, by name ignoring
+// case and the difference between '-', '_' and ' ', or as the unique tool
+// within a small edit distance of name.
+func matchTool(tools []Tool, name string) (Tool, bool) {
+	for _, t := range tools {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	if name == "" {
+		return Tool{}, false
+	}
+
+	fold := strings.NewReplacer("-", "_", " ", "_")
+	folded := fold.Replace(strings.ToLower(name))
+	for _, t := range tools {
+		if fold.Replace(strings.ToLower(t.Name)) == folded {
+			return t, true
+		}
+	}
+
+	names := make([]string, len(tools))
+	for i, t := range tools {
+		names[i] = fold.Replace(strings.ToLower(t.Name))
+	}
+	if i := closestName(folded, names); i >= 0 {
+		return tools[i], true
+	}
+	return Tool{}, false
+}
+
+// closestName returns the index of the unique candidate within the edit
+// distance allowed for name (a third of its length, at least 1), or -1.
+func closestName(name string, candidates []string) int {
+	limit := max(1, len([]rune(name))/3)
+	best, bestDist, tied := -1, limit+1, false
+	for i, c := range candidates {
+		d := levenshtein(name, c)
+		switch {
+		case d < bestDist:
+			best, bestDist, tied = i, d, false
+		case d == bestDist:
+			tied = true
+		}
+	}
+	if tied {
+		return -1
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between a and b in runes.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// toolSchema is the subset of JSON Schema RepairToolCall understands.
+type toolSchema struct {
+	Type                 schemaTypes            `json:"type"`
+	Properties           map[string]*toolSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	AdditionalProperties *bool                  `json:"-"`
+	Items                *toolSchema            `json:"items"`
+	Enum                 []any                  `json:"enum"`
+}
+
+// UnmarshalJSON reads additionalProperties only when it is a boolean.
+func (s *toolSchema) UnmarshalJSON(data []byte) error {
+	type plain toolSchema
+	var aux struct {
+		plain
+		AdditionalProperties json.RawMessage `json:"additionalProperties"`
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*s = toolSchema(aux.plain)
+	var b bool
+	if json.Unmarshal(aux.AdditionalProperties, &b) == nil {
+		s.AdditionalProperties = &b
+	}
+	return nil
+}
+
+// schemaTypes is the type keyword, a single name or a list of names.
+type schemaTypes []string
+
+// UnmarshalJSON accepts "type": "string" as well as "type": ["string", "null"].
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if json.Unmarshal(data, &one) == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// schemaCoercer coerces a tree to a schema, collecting what does not fit.
+// With validate set it only collects, leaving the tree as it is.
+type schemaCoercer struct {
+	opts     []Option
+	validate bool
+	problems []ToolCallProblem
+}
+
+func (c *schemaCoercer) problem(path, msg string) {
+	c.problems = append(c.problems, ToolCallProblem{Path: path, Message: msg})
+}
+
+// coerce returns n, or the node replacing it, fitted to s.
+func (c *schemaCoercer) coerce(path string, s *toolSchema, n *Node) *Node {
+	if s == nil {
+		return n
+	}
+
+	if len(s.Type) > 0 && !s.Type.matches(n) {
+		coerced := false
+		for i := 0; i < len(s.Type) && !c.validate; i++ {
+			if v := c.coerceType(s.Type[i], n); v != nil {
+				v.Start, v.End = n.Start, n.End
+				v.addRepair(RepairSchemaCoercion)
+				n, coerced = v, true
+				break
+			}
+		}
+		if !coerced {
+			c.problem(path, "expected "+strings.Join(s.Type, " or ")+", got "+n.Kind.String())
+			return n
+		}
+	}
+
+	if len(s.Enum) > 0 {
+		n = c.coerceEnum(path, s.Enum, n)
+	}
+
+	switch n.Kind {
+	case ObjectNode:
+		c.coerceObject(path, s, n)
+	case ArrayNode:
+		for i, e := range n.Children {
+			n.Children[i] = c.coerce(path+"/"+strconv.Itoa(i), s.Items, e)
+		}
+	}
+	return n
+}
+
+// coerceObject fits the members of n to the properties of s.
+func (c *schemaCoercer) coerceObject(path string, s *toolSchema, n *Node) {
+	if len(s.Properties) > 0 {
+		var missing []string
+		for name := range s.Properties {
+			if n.member(name) < 0 {
+				missing = append(missing, name)
+			}
+		}
+		sort.Strings(missing)
+
+		for i := range n.Members {
+			m := &n.Members[i]
+			if _, ok := s.Properties[m.Key]; ok {
+				continue
+			}
+			if j := closestName(m.Key, missing); j >= 0 && !c.validate {
+				m.Key = missing[j]
+				m.Repairs = append(m.Repairs, RepairSchemaCoercion)
+				missing = append(missing[:j], missing[j+1:]...)
+				continue
+			}
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				c.problem(path+"/"+escapePointerToken(m.Key), "unexpected property")
+			}
+		}
+	}
+
+	for i := range n.Members {
+		m := &n.Members[i]
+		m.Value = c.coerce(path+"/"+escapePointerToken(m.Key), s.Properties[m.Key], m.Value)
+	}
+	for _, name := range s.Required {
+		if n.member(name) < 0 {
+			c.problem(path+"/"+escapePointerToken(name), "missing required property")
+		}
+	}
+}
+
+// coerceEnum replaces a string differing from an enum value only in case or
+// surrounding spaces with that value.
+func (c *schemaCoercer) coerceEnum(path string, enum []any, n *Node) *Node {
+	v := n.Interface()
+	for _, e := range enum {
+		if enumEqual(e, v) {
+			return n
+		}
+	}
+	if n.Kind == StringNode && !c.validate {
+		s := strings.TrimSpace(n.str())
+		for _, e := range enum {
+			if es, ok := e.(string); ok && strings.EqualFold(es, s) {
+				v := newScalarNode(es, n.Start, n.End)
+				v.Repairs = append(v.Repairs, n.Repairs...)
+				v.addRepair(RepairSchemaCoercion)
+				return v
+			}
+		}
+	}
+
+	values := make([]string, 0, len(enum))
+	for _, e := range enum {
+		bs, _ := json.Marshal(e)
+		values = append(values, string(bs))
+	}
+	c.problem(path, "must be one of "+strings.Join(values, ", "))
+	return n
+}
+
+// enumEqual compares a schema enum value with a node value.
+func enumEqual(e, v any) bool {
+	if ef, ok := e.(float64); ok {
+		vf, ok := numberValue(v)
+		return ok && ef == vf
+	}
+	return e == v
+}
+
+// matches reports whether n has one of the types.
+func (t schemaTypes) matches(n *Node) bool {
+	for _, name := range t {
+		switch name {
+		case "string":
+			if n.Kind == StringNode {
+				return true
+			}
+		case "number":
+			if n.Kind == NumberNode {
+				return true
+			}
+		case "integer":
+			if f, ok := numberValue(n.Value); ok && n.Kind == NumberNode && f == math.Trunc(f) {
+				return true
+			}
+		case "boolean":
+			if n.Kind == BoolNode {
+				return true
+			}
+		case "null":
+			if n.Kind == NullNode {
+				return true
+			}
+		case "array":
+			if n.Kind == ArrayNode {
+				return true
+			}
+		case "object":
+			if n.Kind == ObjectNode {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// coerceType converts n to the schema type t, or returns nil if it cannot.
+func (c *schemaCoercer) coerceType(t string, n *Node) *Node {
+	s := strings.TrimSpace(n.str())
+	switch t {
+	case "string":
+		switch n.Kind {
+		case NumberNode:
+			if num, ok := n.Value.(json.Number); ok {
+				return &Node{Kind: StringNode, Value: num.String()}
+			}
+			if f, ok := numberValue(n.Value); ok {
+				return &Node{Kind: StringNode, Value: strconv.FormatFloat(f, 'f', -1, 64)}
+			}
+		case BoolNode:
+			return &Node{Kind: StringNode, Value: strconv.FormatBool(n.Value == true)}
+		}
+	case "number", "integer":
+		// text is the number as written, kept for integers a float64
+		// cannot hold exactly
+		var f float64
+		var ok bool
+		var text string
+		switch n.Kind {
+		case StringNode:
+			parsed, err := strconv.ParseFloat(s, 64)
+			f, ok = parsed, err == nil && !math.IsInf(parsed, 0) && !math.IsNaN(parsed)
+			if ok && json.Valid([]byte(s)) {
+				text = s
+			}
+		case NumberNode:
+			f, ok = numberValue(n.Value)
+			if num, isNum := n.Value.(json.Number); isNum {
+				text = num.String()
+			}
+		}
+		if !ok || (t == "integer" && f != math.Trunc(f)) {
+			return nil
+		}
+		if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+			return &Node{Kind: NumberNode, Value: json.Number(strconv.FormatInt(int64(f), 10))}
+		}
+		if text != "" {
+			return &Node{Kind: NumberNode, Value: json.Number(text)}
+		}
+		return &Node{Kind: NumberNode, Value: f}
+	case "boolean":
+		switch n.Kind {
+		case StringNode:
+			switch strings.ToLower(s) {
+			case "true", "yes", "1":
+				return &Node{Kind: BoolNode, Value: true}
+			case "false", "no", "0":
+				return &Node{Kind: BoolNode, Value: false}
+			}
+		case NumberNode:
+			if f, ok := numberValue(n.Value); ok && (f == 0 || f == 1) {
+				return &Node{Kind: BoolNode, Value: f == 1}
+			}
+		}
+	case "null":
+		if n.Kind == StringNode && strings.EqualFold(s, "null") {
+			return &Node{Kind: NullNode}
+		}
+	case "array":
+		if strings.HasPrefix(s, "[") {
+			if v, err := ParseAST(s, c.opts...); err == nil && v.Kind == ArrayNode {
+				return v
+			}
+		}
+		if n.Kind != NullNode {
+			return &Node{Kind: ArrayNode, Children: []*Node{n}}
+		}
+	case "object":
+		if strings.HasPrefix(s, "{") {
+			if v, err := ParseAST(s, c.opts...); err == nil && v.Kind == ObjectNode {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// numberValue returns the value of a number node as a float64.
+func numberValue(v any) (float64, bool) {
+	switch x := v.(type) {
+	case int:
+		return float64(x), true
+	case float64:
+		return x, true
+	case json.Number:
+		f, err := x.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,194 @@
+package jsonrepair
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+var testTools = []Tool{
+	{
+		Name: "get_weather",
+		Parameters: json.RawMessage(`{
+			"type": "object",
+			"properties": {
+				"city": {"type": "string"},
+				"days": {"type": "integer"},
+				"unit": {"type": "string", "enum": ["celsius", "fahrenheit"]},
+				"tags": {"type": "array", "items": {"type": "string"}},
+				"verbose": {"type": "boolean"}
+			},
+			"required": ["city"],
+			"additionalProperties": false
+		}`),
+	},
+	{
+		Name:       "search",
+		Parameters: json.RawMessage(`{"type": "object", "properties": {"query": {"type": "string"}}, "required": ["query"]}`),
+	},
+	{Name: "ping"},
+}
+
+// Test_RepairToolCall
+//
+//	Description:
+//	param t
+func Test_RepairToolCall(t *testing.T) {
+	tests := []struct {
+		in           string
+		wantName     string
+		want         string
+		wantErr      error
+		wantProblems []ToolCallProblem
+	}{
+		{
+			in:       `{"name": "search", "arguments": "{\"query\": \"go\"}"}`,
+			wantName: "search",
+			want:     `{"query":"go"}`,
+		},
+		{
+			in: `{"name": "get_weather", "arguments": "{\"city\": \"Paris\", \"days\": \"3\", ` +
+				`\"unit\": \"Celsius\", \"tags\": \"x\", \"verbose\": \"yes\""}`,
+			wantName: "get_weather",
+			want:     `{"city":"Paris","days":3,"unit":"celsius","tags":["x"],"verbose":true}`,
+		},
+		{
+			in:       `{"type": "function", "function": {"name": "getWeather", "arguments": "{'citty': 'Oslo', days: 2.0}"}}`,
+			wantName: "get_weather",
+			want:     `{"city":"Oslo","days":2}`,
+		},
+		{
+			in:       `{"name": "serch", "input": {"query": 42}}`,
+			wantName: "search",
+			want:     `{"query":"42"}`,
+		},
+		{
+			in:       `{"name": "search", "input": {"query": 12345678901234567890}}`,
+			wantName: "search",
+			want:     `{"query":"12345678901234567890"}`,
+		},
+		{
+			in:       `{"name": "get_weather", "arguments": "{\"city\": \"Rome\", \"days\": \"12345678901234567890\"}"}`,
+			wantName: "get_weather",
+			want:     `{"city":"Rome","days":12345678901234567890}`,
+		},
+		{
+			in:       "```json\n{\"name\": \"ping\", \"arguments\": \"\"}\n```",
+			wantName: "ping",
+			want:     `{}`,
+		},
+		{
+			in:       `{"name": "get-weather", "input": {"days": "soon", "foo": 1}}`,
+			wantName: "get_weather",
+			want:     `{"days":"soon","foo":1}`,
+			wantErr:  ErrInvalidArguments,
+			wantProblems: []ToolCallProblem{
+				{Path: "/foo", Message: "unexpected property"},
+				{Path: "/days", Message: "expected integer, got string"},
+				{Path: "/city", Message: "missing required property"},
+			},
+		},
+		{
+			in:       `{"name": "get_weather", "arguments": "{\"city\": \"Rome\", \"unit\": \"kelvin\"}"}`,
+			wantName: "get_weather",
+			want:     `{"city":"Rome","unit":"kelvin"}`,
+			wantErr:  ErrInvalidArguments,
+			wantProblems: []ToolCallProblem{
+				{Path: "/unit", Message: `must be one of "celsius", "fahrenheit"`},
+			},
+		},
+		{
+			in:       `{"name": "search", "arguments": "[\"go\"]"}`,
+			wantName: "search",
+			want:     `["go"]`,
+			wantErr:  ErrInvalidArguments,
+			wantProblems: []ToolCallProblem{
+				{Path: "", Message: "arguments must be an object, got array"},
+			},
+		},
+		{
+			in:      `{"name": "browse", "arguments": "{}"}`,
+			wantErr: ErrUnknownTool,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			call, err := RepairToolCall(testTools, tt.in)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RepairToolCall() error = %v, want %v", err, tt.wantErr)
+			}
+			var tcErr *ToolCallError
+			if errors.As(err, &tcErr) && !reflect.DeepEqual(tcErr.Problems, tt.wantProblems) {
+				t.Errorf("Problems = %v, want %v", tcErr.Problems, tt.wantProblems)
+			}
+			if tt.wantName == "" {
+				return
+			}
+			if call.Name != tt.wantName || string(call.Arguments) != tt.want {
+				t.Errorf("RepairToolCall() = %s %s, want %s %s", call.Name, call.Arguments, tt.wantName, tt.want)
+			}
+		})
+	}
+}
+
+// Test_RepairToolCall_Report
+//
+//	Description:
+//	param t
+func Test_RepairToolCall_Report(t *testing.T) {
+	call, err := RepairToolCall(testTools, `{"name": "get_weather", "arguments": "{\"citty\": \"Oslo\", \"days\": \"2\"}"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []RepairEntry
+	for _, r := range call.Report.Repairs {
+		got = append(got, RepairEntry{Flag: r.Flag, Path: r.Path, Key: r.Key})
+	}
+	want := []RepairEntry{
+		{Flag: RepairSchemaCoercion, Path: "/city", Key: true},
+		{Flag: RepairSchemaCoercion, Path: "/days"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Repairs = %v, want %v", got, want)
+	}
+
+	var args struct {
+		City string `json:"city"`
+		Days int    `json:"days"`
+	}
+	if err := call.Decode(&args); err != nil || args.City != "Oslo" || args.Days != 2 {
+		t.Errorf("Decode() = %+v, %v", args, err)
+	}
+
+	_, err = RepairToolCall(testTools, `{"name": "browse"}`)
+	if want := `unknown tool "browse"; available tools: get_weather, search, ping`; err == nil || err.Error() != want {
+		t.Errorf("Error() = %v, want %v", err, want)
+	}
+}
+
+// Test_levenshtein
+//
+//	Description:
+//	param t
+func Test_levenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"search", "search", 0},
+		{"serch", "search", 1},
+		{"kitten", "sitting", 3},
+		{"天气", "天氣", 1},
+	}
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			if got := levenshtein(tt.a, tt.b); got != tt.want {
+				t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
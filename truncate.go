@@ -0,0 +1,86 @@
+package jsonrepair
+
+import (
+	"strings"
+	"unicode"
+)
+
+// parseDocument parses every top-level value of the container and records
+// what was lost if the input ended mid-document.
+func (p *JSONParser) parseDocument() *Node {
+	root := p.collectMultipleTopLevel(p.parseJSON())
+	p.markTruncated(root)
+	return root
+}
+
+// markTruncated flags the last element of a truncated document when the input
+// ended inside it, and drops it if the caller asked for WithDropIncomplete.
+//
+// A document counts as truncated when the parser had to close a string or a
+// container at the end of the input. Its last element is incomplete when it
+// is a string or number running up to the end of the input, or an object
+// member whose value is missing altogether.
+func (p *JSONParser) markTruncated(root *Node) {
+	truncated := false
+	Walk(root, func(_ string, n *Node) bool {
+		truncated = truncated || n.HasRepair(RepairUnclosedArray) ||
+			n.HasRepair(RepairUnclosedObject) || n.HasRepair(RepairUnclosedString)
+		return !truncated
+	})
+	if !truncated {
+		return
+	}
+
+	// Descend to the last element in document order
+	var parent *Node
+	last := root
+	var keyRepairs []RepairFlag
+	for {
+		if n := len(last.Children); n > 0 {
+			parent, last, keyRepairs = last, last.Children[n-1], nil
+			continue
+		}
+		if n := len(last.Members); n > 0 {
+			parent, last, keyRepairs = last, last.Members[n-1].Value, last.Members[n-1].Repairs
+			continue
+		}
+		break
+	}
+
+	end := len(strings.TrimRightFunc(p.container, unicode.IsSpace))
+	if parent == nil || last.End < end {
+		return
+	}
+
+	missing := last.Start == last.End
+	cut := false
+	switch last.Kind {
+	case StringNode:
+		cut = !missing && (last.HasRepair(RepairUnclosedString) || last.HasRepair(RepairMissingQuotes))
+	case NumberNode:
+		cut = true
+	}
+	for _, f := range keyRepairs {
+		cut = cut || f == RepairUnclosedString
+	}
+	if !cut && !missing {
+		return
+	}
+
+	if !p.opts.dropIncomplete {
+		if cut {
+			last.addRepair(RepairTruncatedToken)
+		}
+		return
+	}
+
+	if len(parent.Children) > 0 {
+		parent.Children = parent.Children[:len(parent.Children)-1]
+	} else {
+		parent.Members = parent.Members[:len(parent.Members)-1]
+	}
+	parent.addRepair(RepairDroppedIncomplete)
+	if cut {
+		parent.addRepair(RepairTruncatedToken)
+	}
+}
@@ -0,0 +1,65 @@
+package jsonrepair
+
+import (
+	"strconv"
+	"testing"
+)
+
+// Test_RepairJSON_LiteralVocabulary
+//
+//	Description:
+//	param t
+func Test_RepairJSON_LiteralVocabulary(t *testing.T) {
+	tests := []struct {
+		in         string
+		vocabulary map[string]any
+		want       string
+	}{
+		{
+			in:   `{"a": yes, "b": No, "c": ON, "d": off, "e": nil, "f": None, "g": undefined, "h": N/A, "i": true}`,
+			want: `{"a":true,"b":false,"c":true,"d":false,"e":null,"f":null,"g":null,"h":null,"i":true}`,
+		},
+		{
+			in:   `{"合格": 是, "退款": 否, "確認": はい, "list": [yes, no]}`,
+			want: `{"合格":true,"退款":false,"確認":true,"list":[true,false]}`,
+		},
+		{
+			in:   `{"answer": "yes", "none": "None", yes: 1}`,
+			want: `{"answer":"yes","none":"None","yes":1}`,
+		},
+		{
+			in:   `{"nothing": nothing, "nope": nope}`,
+			want: `{"nothing":"nothing","nope":"nope"}`,
+		},
+		{
+			in:         `{"a": yes, "b": Y, "c": no}`,
+			vocabulary: map[string]any{"y": true, "yes": true},
+			want:       `{"a":true,"b":true,"c":"no"}`,
+		},
+		{
+			in:   `{"a": no way, "b": no , "c": off}`,
+			want: `{"a":"no way","b":false,"c":false}`,
+		},
+		{
+			in:         `{"a": yes, "b": YES}`,
+			vocabulary: map[string]any{"YES": false, "Yes": nil, "yes": true},
+			want:       `{"a":false,"b":false}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			got, err := RepairJSON(tt.in, WithLiteralVocabulary(tt.vocabulary))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !jsonStringsEqual(got, tt.want) {
+				t.Errorf("RepairJSON() = %v, want %v, param in is %v", got, tt.want, tt.in)
+			}
+		})
+	}
+
+	if got, _ := RepairJSON(`{"a": yes}`); !jsonStringsEqual(got, `{"a":"yes"}`) {
+		t.Errorf("RepairJSON() without vocabulary = %v", got)
+	}
+}
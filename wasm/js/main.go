@@ -0,0 +1,60 @@
+//go:build js && wasm
+
+// Command js registers repairJSON(src, options) on the JavaScript global
+// object. Build it with
+//
+//	GOOS=js GOARCH=wasm go build -o jsonrepair.wasm ./wasm/js
+//
+// and load it with the wasm_exec.js shipped with Go. repairJSON returns an
+// object with the repaired document in json, the repairs with their
+// confidence, or error; options is an optional object of wasm.Options.
+package main
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/RealAlexandreAI/json-repair/wasm"
+)
+
+// main
+//
+//	@Description:
+func main() {
+	js.Global().Set("repairJSON", js.FuncOf(repairJSON))
+	select {}
+}
+
+// repairJSON
+//
+//	Description: the JavaScript repairJSON(src, options) function
+//	param this
+//	param args
+//	return any
+func repairJSON(_ js.Value, args []js.Value) any {
+	res := call(args)
+	bs, err := json.Marshal(res)
+	if err != nil {
+		bs, _ = json.Marshal(wasm.Result{Error: err.Error()})
+	}
+	return js.Global().Get("JSON").Call("parse", string(bs))
+}
+
+// call validates the arguments of repairJSON and runs the repair.
+func call(args []js.Value) wasm.Result {
+	if len(args) == 0 || args[0].Type() != js.TypeString {
+		return wasm.Result{Error: "repairJSON: src must be a string"}
+	}
+
+	var o wasm.Options
+	if len(args) > 1 && !args[1].IsUndefined() && !args[1].IsNull() {
+		if args[1].Type() != js.TypeObject {
+			return wasm.Result{Error: "repairJSON: options must be an object"}
+		}
+		raw := js.Global().Get("JSON").Call("stringify", args[1]).String()
+		if err := json.Unmarshal([]byte(raw), &o); err != nil {
+			return wasm.Result{Error: "repairJSON: invalid options: " + err.Error()}
+		}
+	}
+	return wasm.RepairJSON(args[0].String(), o)
+}
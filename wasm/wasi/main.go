@@ -0,0 +1,61 @@
+//go:build wasip1
+
+// Command wasi repairs the JSON read from standard input under a WASI
+// runtime. Build it with
+//
+//	GOOS=wasip1 GOARCH=wasm go build -o jsonrepair.wasm ./wasm/wasi
+//
+// By default it writes the repaired document; with -report it writes the
+// wasm.Result as JSON instead. -options takes a JSON object of
+// wasm.Options.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/RealAlexandreAI/json-repair/wasm"
+)
+
+// main
+//
+//	@Description:
+func main() {
+	report := flag.Bool("report", false, "Write the repair report as JSON")
+	options := flag.String("options", "", "Repair options as a JSON object")
+	flag.Parse()
+
+	var o wasm.Options
+	if *options != "" {
+		if err := json.Unmarshal([]byte(*options), &o); err != nil {
+			fail(fmt.Sprintf("invalid options: %s", err))
+		}
+	}
+	src, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		fail(err.Error())
+	}
+
+	res := wasm.RepairJSON(string(src), o)
+	if !*report {
+		if res.Error != "" {
+			fail(res.Error)
+		}
+		fmt.Print(res.JSON)
+		return
+	}
+	bs, err := json.Marshal(res)
+	if err != nil {
+		fail(err.Error())
+	}
+	fmt.Print(string(bs))
+}
+
+// fail reports msg and exits with status 1.
+func fail(msg string) {
+	fmt.Fprintf(os.Stderr, "[json-repair] %s\n", msg)
+	os.Exit(1)
+}
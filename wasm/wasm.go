@@ -0,0 +1,170 @@
+// Package wasm holds the API shared by the WebAssembly builds of the
+// repairer: wasm/js registers a repairJSON function for browsers and
+// Node.js (GOOS=js GOARCH=wasm), and wasm/wasi is a command for WASI
+// runtimes and edge workers (GOOS=wasip1 GOARCH=wasm). Options and results
+// cross the JavaScript boundary as JSON.
+package wasm
+
+import (
+	"fmt"
+
+	"github.com/RealAlexandreAI/json-repair"
+)
+
+// Options
+//
+//	Description: the repair options a WebAssembly caller can set. Enum
+//	options take the lower-case name of the matching jsonrepair constant
+//	without its prefix, e.g. "reencode" for NestedJSONReencode; an empty
+//	string keeps the default.
+type Options struct {
+	Python         bool   `json:"python,omitempty"`
+	JavaScript     bool   `json:"javascript,omitempty"`
+	DropIncomplete bool   `json:"dropIncomplete,omitempty"`
+	UnwrapEncoded  bool   `json:"unwrapEncoded,omitempty"`
+	NestedJSON     string `json:"nestedJSON,omitempty"`
+	InvalidUTF8    string `json:"invalidUTF8,omitempty"`
+	Elision        string `json:"elision,omitempty"`
+	Units          string `json:"units,omitempty"`
+	Locale         string `json:"locale,omitempty"`
+}
+
+// Repair
+//
+//	Description: a single repair of a Result
+type Repair struct {
+	Flag       string  `json:"flag"`
+	Path       string  `json:"path"`
+	Key        bool    `json:"key,omitempty"`
+	Start      int     `json:"start"`
+	End        int     `json:"end"`
+	Confidence float64 `json:"confidence"`
+}
+
+// Result
+//
+//	Description: the outcome of RepairJSON. JSON is the repaired document;
+//	the other fields mirror jsonrepair.Report. Error is set instead when the
+//	options are invalid or the repair failed.
+type Result struct {
+	JSON           string   `json:"json"`
+	Repaired       bool     `json:"repaired"`
+	Confidence     float64  `json:"confidence"`
+	Repairs        []Repair `json:"repairs"`
+	Truncated      bool     `json:"truncated"`
+	AutoClosed     []string `json:"autoClosed"`
+	TruncatedToken bool     `json:"truncatedToken"`
+	EncodedLayers  int      `json:"encodedLayers"`
+	Error          string   `json:"error,omitempty"`
+}
+
+// RepairJSON
+//
+//	Description: repairs src with o and reports what was repaired
+//	param src
+//	param o
+//	return Result
+func RepairJSON(src string, o Options) Result {
+	opts, err := o.repairOptions()
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+	dst, report, err := jsonrepair.RepairJSONWithReport(src, opts...)
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+
+	res := Result{
+		JSON:           dst,
+		Repaired:       report.Repaired(),
+		Confidence:     report.Confidence,
+		Repairs:        []Repair{},
+		Truncated:      report.Truncated,
+		AutoClosed:     report.AutoClosed,
+		TruncatedToken: report.TruncatedToken,
+		EncodedLayers:  report.EncodedLayers,
+	}
+	for _, e := range report.Repairs {
+		res.Repairs = append(res.Repairs, Repair{
+			Flag:       string(e.Flag),
+			Path:       e.Path,
+			Key:        e.Key,
+			Start:      e.Start,
+			End:        e.End,
+			Confidence: e.Confidence,
+		})
+	}
+	return res
+}
+
+// repairOptions translates o into jsonrepair options.
+func (o Options) repairOptions() ([]jsonrepair.Option, error) {
+	var opts []jsonrepair.Option
+	if o.Python {
+		opts = append(opts, jsonrepair.WithPythonLiterals())
+	}
+	if o.JavaScript {
+		opts = append(opts, jsonrepair.WithJavaScriptLiterals(nil))
+	}
+	if o.DropIncomplete {
+		opts = append(opts, jsonrepair.WithDropIncomplete())
+	}
+	if o.UnwrapEncoded {
+		opts = append(opts, jsonrepair.WithUnwrapEncoded())
+	}
+
+	switch o.NestedJSON {
+	case "", "off":
+	case "parse":
+		opts = append(opts, jsonrepair.WithNestedJSON(jsonrepair.NestedJSONParse))
+	case "reencode":
+		opts = append(opts, jsonrepair.WithNestedJSON(jsonrepair.NestedJSONReencode))
+	default:
+		return nil, fmt.Errorf("invalid nestedJSON option: %q", o.NestedJSON)
+	}
+
+	switch o.InvalidUTF8 {
+	case "", "keep":
+	case "replace":
+		opts = append(opts, jsonrepair.WithInvalidUTF8(jsonrepair.InvalidUTF8Replace))
+	case "windows1252":
+		opts = append(opts, jsonrepair.WithInvalidUTF8(jsonrepair.InvalidUTF8Windows1252))
+	case "error":
+		opts = append(opts, jsonrepair.WithInvalidUTF8(jsonrepair.InvalidUTF8Error))
+	default:
+		return nil, fmt.Errorf("invalid invalidUTF8 option: %q", o.InvalidUTF8)
+	}
+
+	switch o.Elision {
+	case "", "drop":
+	case "keep":
+		opts = append(opts, jsonrepair.WithElisionPolicy(jsonrepair.ElisionKeep))
+	case "error":
+		opts = append(opts, jsonrepair.WithElisionPolicy(jsonrepair.ElisionError))
+	default:
+		return nil, fmt.Errorf("invalid elision option: %q", o.Elision)
+	}
+
+	switch o.Units {
+	case "", "ignore":
+	case "number":
+		opts = append(opts, jsonrepair.WithUnitPolicy(jsonrepair.UnitsNumber))
+	case "string":
+		opts = append(opts, jsonrepair.WithUnitPolicy(jsonrepair.UnitsString))
+	case "split":
+		opts = append(opts, jsonrepair.WithUnitPolicy(jsonrepair.UnitsSplit))
+	default:
+		return nil, fmt.Errorf("invalid units option: %q", o.Units)
+	}
+
+	switch o.Locale {
+	case "":
+	case "english":
+		opts = append(opts, jsonrepair.WithNumberLocale(jsonrepair.LocaleEnglish))
+	case "european":
+		opts = append(opts, jsonrepair.WithNumberLocale(jsonrepair.LocaleEuropean))
+	default:
+		return nil, fmt.Errorf("invalid locale option: %q", o.Locale)
+	}
+	return opts, nil
+}
@@ -0,0 +1,186 @@
+package wasm
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// Test_RepairJSON
+//
+//	Description:
+//	param t
+func Test_RepairJSON(t *testing.T) {
+	tests := []struct {
+		in        string
+		opts      Options
+		want      string
+		wantFlags []string
+		wantErr   string
+	}{
+		{
+			in:   `{"a": 1}`,
+			want: `{"a":1}`,
+		},
+		{
+			in:        `[1, 2, 3,]`,
+			want:      `[1,2,3]`,
+			wantFlags: []string{"trailing_comma"},
+		},
+		{
+			in:        `{'a': True, 'b': None}`,
+			opts:      Options{Python: true},
+			want:      `{"a":true,"b":null}`,
+			wantFlags: []string{"python_literals"},
+		},
+		{
+			in:        `{"w": 10kg}`,
+			opts:      Options{Units: "split"},
+			want:      `{"w":{"unit":"kg","value":10}}`,
+			wantFlags: []string{"number_unit"},
+		},
+		{
+			in:      `{"a": 1}`,
+			opts:    Options{NestedJSON: "deep"},
+			wantErr: `invalid nestedJSON option: "deep"`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			res := RepairJSON(tt.in, tt.opts)
+			if res.Error != tt.wantErr {
+				t.Fatalf("RepairJSON() error = %q, want %q", res.Error, tt.wantErr)
+			}
+			if res.JSON != tt.want {
+				t.Errorf("RepairJSON() = %v, want %v", res.JSON, tt.want)
+			}
+			var flags []string
+			for _, r := range res.Repairs {
+				flags = append(flags, r.Flag)
+			}
+			if !reflect.DeepEqual(flags, tt.wantFlags) || res.Repaired != (len(tt.wantFlags) > 0) {
+				t.Errorf("RepairJSON() flags = %v, want %v", flags, tt.wantFlags)
+			}
+		})
+	}
+}
+
+// Test_BuildJS
+//
+//	Description: compiles the GOOS=js build
+//	param t
+func Test_BuildJS(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping WebAssembly build in short mode")
+	}
+	buildWasm(t, "js", "./js")
+}
+
+// Test_WASI
+//
+//	Description: runs the WASI build headlessly with wasmtime, wazero or
+//	Node.js, whichever is installed, and skips when none is
+//	param t
+func Test_WASI(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping WebAssembly build in short mode")
+	}
+	run := wasiRunner(t)
+	bin := buildWasm(t, "wasip1", "./wasi")
+
+	tests := []struct {
+		in   string
+		args []string
+		want string
+	}{
+		{
+			in:   "{'employees':['John', 'Anna', ",
+			want: `{"employees":["John","Anna"]}`,
+		},
+		{
+			in:   "{'a': True}",
+			args: []string{"-options", `{"python":true}`},
+			want: `{"a":true}`,
+		},
+		{
+			in:   "[1, 2,]",
+			args: []string{"-report"},
+			want: `{"json":"[1,2]","repaired":true,"confidence":0.99,` +
+				`"repairs":[{"flag":"trailing_comma","path":"","start":0,"end":7,"confidence":0.99}],` +
+				`"truncated":false,"autoClosed":null,"truncatedToken":false,"encodedLayers":0}`,
+		},
+	}
+
+	for caseNo, tt := range tests {
+		t.Run("CASE-"+strconv.Itoa(caseNo+1), func(t *testing.T) {
+			cmd := run(bin, tt.args...)
+			cmd.Stdin = strings.NewReader(tt.in)
+			var stderr bytes.Buffer
+			cmd.Stderr = &stderr
+			out, err := cmd.Output()
+			if err != nil {
+				t.Fatalf("%v: %v\n%s", cmd, err, stderr.String())
+			}
+			if string(out) != tt.want {
+				t.Errorf("output = %s, want %s", out, tt.want)
+			}
+		})
+	}
+}
+
+// buildWasm builds the package pkg for GOOS=goos GOARCH=wasm and returns the
+// path of the binary.
+func buildWasm(t *testing.T, goos, pkg string) string {
+	t.Helper()
+	gobin := filepath.Join(runtime.GOROOT(), "bin", "go")
+	bin := filepath.Join(t.TempDir(), "jsonrepair.wasm")
+	cmd := exec.Command(gobin, "build", "-o", bin, pkg)
+	cmd.Env = append(os.Environ(), "GOOS="+goos, "GOARCH=wasm")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("go build %s: %v\n%s", pkg, err, out)
+	}
+	return bin
+}
+
+// nodeWASI starts the module named by argv[2] with Node.js' WASI support,
+// passing it the remaining arguments.
+const nodeWASI = `const { WASI } = require('node:wasi');
+const fs = require('node:fs');
+const wasi = new WASI({ version: 'preview1', args: process.argv.slice(2), env: {} });
+WebAssembly.instantiate(fs.readFileSync(process.argv[2]), wasi.getImportObject())
+  .then(({ instance }) => { process.exitCode = wasi.start(instance); });
+`
+
+// wasiRunner returns a function building the command that runs a WASI
+// module, or skips t when no WASI runtime is installed.
+func wasiRunner(t *testing.T) func(bin string, args ...string) *exec.Cmd {
+	t.Helper()
+	if path, err := exec.LookPath("wasmtime"); err == nil {
+		return func(bin string, args ...string) *exec.Cmd {
+			return exec.Command(path, append([]string{"run", bin}, args...)...)
+		}
+	}
+	if path, err := exec.LookPath("wazero"); err == nil {
+		return func(bin string, args ...string) *exec.Cmd {
+			return exec.Command(path, append([]string{"run", bin}, args...)...)
+		}
+	}
+	if path, err := exec.LookPath("node"); err == nil {
+		script := filepath.Join(t.TempDir(), "wasi.js")
+		if err := os.WriteFile(script, []byte(nodeWASI), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return func(bin string, args ...string) *exec.Cmd {
+			return exec.Command(path, append([]string{"--no-warnings", script, bin}, args...)...)
+		}
+	}
+	t.Skip("no WASI runtime (wasmtime, wazero or node) installed")
+	return nil
+}